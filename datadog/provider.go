@@ -3,6 +3,7 @@ package datadog
 import (
 	"errors"
 	"log"
+	"net/http"
 
 	cleanhttp "github.com/hashicorp/go-cleanhttp"
 	"github.com/hashicorp/terraform/helper/logging"
@@ -29,6 +30,11 @@ func Provider() terraform.ResourceProvider {
 				Optional:    true,
 				DefaultFunc: schema.EnvDefaultFunc("DATADOG_HOST", nil),
 			},
+			"user_agent_suffix": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "A suffix appended to the User-Agent header of requests sent to the Datadog API.",
+			},
 		},
 
 		ResourcesMap: map[string]*schema.Resource{
@@ -68,6 +74,13 @@ func providerConfigure(d *schema.ResourceData) (interface{}, error) {
 	}
 
 	c := cleanhttp.DefaultClient()
+	// The User-Agent is set before logging so the debug logs show the header actually sent
+	if suffix := d.Get("user_agent_suffix").(string); suffix != "" {
+		c.Transport = &userAgentTransport{
+			inner:  c.Transport,
+			suffix: suffix,
+		}
+	}
 	c.Transport = logging.NewTransport("Datadog", c.Transport)
 	client.HttpClient = c
 
@@ -85,3 +98,31 @@ func providerConfigure(d *schema.ResourceData) (interface{}, error) {
 
 	return client, nil
 }
+
+// defaultUserAgent is the User-Agent sent when a request doesn't set one, which is
+// the case for every request built by the Datadog API client
+const defaultUserAgent = "terraform-provider-datadog"
+
+// userAgentTransport appends a suffix to the User-Agent header of every request sent
+// to the Datadog API
+type userAgentTransport struct {
+	inner  http.RoundTripper
+	suffix string
+}
+
+func (t *userAgentTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// A RoundTripper must not modify the request it is given
+	clone := new(http.Request)
+	*clone = *req
+	clone.Header = make(http.Header, len(req.Header))
+	for k, v := range req.Header {
+		clone.Header[k] = append([]string(nil), v...)
+	}
+	req = clone
+	userAgent := req.Header.Get("User-Agent")
+	if userAgent == "" {
+		userAgent = defaultUserAgent
+	}
+	req.Header.Set("User-Agent", userAgent+" "+t.suffix)
+	return t.inner.RoundTrip(req)
+}
@@ -1,6 +1,8 @@
 package datadog
 
 import (
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"testing"
 
@@ -28,6 +30,75 @@ func TestProvider_impl(t *testing.T) {
 	var _ terraform.ResourceProvider = Provider()
 }
 
+func TestProvider_userAgentSuffix(t *testing.T) {
+	var userAgent string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userAgent = r.Header.Get("User-Agent")
+		w.Write([]byte(`{"valid": true}`))
+	}))
+	defer server.Close()
+
+	provider := Provider().(*schema.Provider)
+	d := schema.TestResourceDataRaw(t, provider.Schema, map[string]interface{}{
+		"api_key":           "api-key",
+		"app_key":           "app-key",
+		"api_url":           server.URL,
+		"user_agent_suffix": "support-ticket-1234",
+	})
+	if _, err := providerConfigure(d); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if expected := defaultUserAgent + " support-ticket-1234"; userAgent != expected {
+		t.Fatalf("Expected the User-Agent to be %q - instead saw %q", expected, userAgent)
+	}
+}
+
+func TestUserAgentTransport(t *testing.T) {
+	cases := []struct {
+		UserAgent string
+		Expected  string
+	}{
+		{
+			UserAgent: "",
+			Expected:  defaultUserAgent + " support-ticket-1234",
+		},
+		{
+			UserAgent: "custom-client/1.0",
+			Expected:  "custom-client/1.0 support-ticket-1234",
+		},
+	}
+
+	for _, tc := range cases {
+		var userAgent string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			userAgent = r.Header.Get("User-Agent")
+		}))
+
+		req, err := http.NewRequest("GET", server.URL, nil)
+		if err != nil {
+			t.Fatalf("err: %s", err)
+		}
+		if tc.UserAgent != "" {
+			req.Header.Set("User-Agent", tc.UserAgent)
+		}
+		transport := &userAgentTransport{inner: http.DefaultTransport, suffix: "support-ticket-1234"}
+		resp, err := transport.RoundTrip(req)
+		if err != nil {
+			t.Fatalf("err: %s", err)
+		}
+		resp.Body.Close()
+		server.Close()
+
+		if userAgent != tc.Expected {
+			t.Fatalf("Expected the User-Agent to be %q - instead saw %q", tc.Expected, userAgent)
+		}
+		if v := req.Header.Get("User-Agent"); v != tc.UserAgent {
+			t.Fatalf("Expected the original request User-Agent to be left as %q - instead saw %q", tc.UserAgent, v)
+		}
+	}
+}
+
 func testAccPreCheck(t *testing.T) {
 	if v := os.Getenv("DATADOG_API_KEY"); v == "" {
 		t.Fatal("DATADOG_API_KEY must be set for acceptance tests")
@@ -2,11 +2,16 @@ package datadog
 
 import (
 	"fmt"
+	"log"
+	"net/url"
+	"regexp"
+	"sort"
 
 	"strconv"
 	"strings"
 
 	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
 	datadog "github.com/zorkian/go-datadog-api"
 )
 
@@ -20,6 +25,7 @@ func resourceDatadogDashboard() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: resourceDatadogDashboardImport,
 		},
+		CustomizeDiff: resourceDatadogDashboardCustomizeDiff,
 		Schema: map[string]*schema.Schema{
 			"title": {
 				Type:        schema.TypeString,
@@ -65,10 +71,202 @@ func resourceDatadogDashboard() *schema.Resource {
 				Description: "The list of handles of users to notify when changes are made to this dashboard.",
 				Elem:        &schema.Schema{Type: schema.TypeString},
 			},
+			"url": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The URL of the dashboard.",
+			},
 		},
 	}
 }
 
+func resourceDatadogDashboardCustomizeDiff(diff *schema.ResourceDiff, meta interface{}) error {
+	layoutType := diff.Get("layout_type").(string)
+	terraformWidgets := diff.Get("widget").([]interface{})
+	errs := getWidgetDefinitionErrors("widget", terraformWidgets)
+	errs = append(errs, getWidgetRequestQueryErrors("widget", terraformWidgets, diff.NewValueKnown)...)
+	if layoutType == "free" {
+		errs = append(errs, getWidgetLayoutSizeErrors(terraformWidgets)...)
+	}
+	if len(errs) > 0 {
+		return widgetErrors(errs)
+	}
+	for _, warning := range getNoteTickWarnings("widget", terraformWidgets) {
+		log.Printf("[WARN] %s", warning)
+	}
+	return nil
+}
+
+// Helper to check that every widget, including the ones nested in groups, sets
+// exactly one definition. This surfaces at plan time what buildDatadogWidget
+// would otherwise only report at apply time.
+func getWidgetDefinitionErrors(prefix string, terraformWidgets []interface{}) []string {
+	var errs []string
+	for i, _widget := range terraformWidgets {
+		terraformWidget, ok := _widget.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		var definitionKeys []string
+		for _, definition := range getWidgetDefinitions() {
+			if _def, ok := terraformWidget[definition.definitionKey].([]interface{}); ok && len(_def) != 0 {
+				definitionKeys = append(definitionKeys, definition.definitionKey)
+			}
+		}
+		switch len(definitionKeys) {
+		case 0:
+			errs = append(errs, fmt.Sprintf("%s.%d: exactly one widget definition is required, got none", prefix, i))
+		case 1:
+			break
+		default:
+			errs = append(errs, fmt.Sprintf("%s.%d: exactly one widget definition is required, got %s",
+				prefix, i, strings.Join(definitionKeys, ", ")))
+		}
+		if _def, ok := terraformWidget["group_definition"].([]interface{}); ok && len(_def) != 0 {
+			if groupDefinition, ok := _def[0].(map[string]interface{}); ok {
+				if v, ok := groupDefinition["widget"].([]interface{}); ok {
+					groupPrefix := fmt.Sprintf("%s.%d.group_definition.0.widget", prefix, i)
+					errs = append(errs, getWidgetDefinitionErrors(groupPrefix, v)...)
+				}
+			}
+		}
+	}
+	return errs
+}
+
+// Helper to check that the widgets of a free dashboard have a positive width and height
+func getWidgetLayoutSizeErrors(terraformWidgets []interface{}) []string {
+	var errs []string
+	for i, _widget := range terraformWidgets {
+		terraformWidget, ok := _widget.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		terraformLayout, ok := terraformWidget["layout"].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		for _, key := range []string{"width", "height"} {
+			// Values that can't be parsed yet, e.g. not yet computed, are left to the API
+			_v, ok := terraformLayout[key].(string)
+			if !ok || len(_v) == 0 {
+				continue
+			}
+			if v, err := strconv.ParseFloat(_v, 64); err == nil && v <= 0 {
+				errs = append(errs, fmt.Sprintf("widget.%d.layout.%s must be greater than 0, got %s", i, key, _v))
+			}
+		}
+	}
+	return errs
+}
+
+// Helper to flag notes setting tick_pos or tick_edge without show_tick, in which
+// case the tick settings have no effect. Notes nested in groups are checked too.
+func getNoteTickWarnings(prefix string, terraformWidgets []interface{}) []string {
+	var warnings []string
+	for i, _widget := range terraformWidgets {
+		terraformWidget, ok := _widget.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if _def, ok := terraformWidget["group_definition"].([]interface{}); ok && len(_def) != 0 {
+			if groupDefinition, ok := _def[0].(map[string]interface{}); ok {
+				if v, ok := groupDefinition["widget"].([]interface{}); ok {
+					groupPrefix := fmt.Sprintf("%s.%d.group_definition.0.widget", prefix, i)
+					warnings = append(warnings, getNoteTickWarnings(groupPrefix, v)...)
+				}
+			}
+			continue
+		}
+		_def, ok := terraformWidget["note_definition"].([]interface{})
+		if !ok || len(_def) == 0 {
+			continue
+		}
+		noteDefinition, ok := _def[0].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if showTick, ok := noteDefinition["show_tick"].(bool); ok && showTick {
+			continue
+		}
+		for _, key := range []string{"tick_pos", "tick_edge"} {
+			if v, ok := noteDefinition[key].(string); ok && len(v) != 0 {
+				warnings = append(warnings, fmt.Sprintf(
+					"%s.%d.note_definition.0.%s has no effect unless show_tick is true", prefix, i, key))
+			}
+		}
+	}
+	return warnings
+}
+
+// Widget definitions whose request builders require exactly one query per request
+var widgetRequestQueryDefinitions = []string{
+	"change_definition",
+	"heatmap_definition",
+	"query_value_definition",
+	"timeseries_definition",
+	"toplist_definition",
+}
+
+// Helper to check that the requests of the widgets above, including the ones nested
+// in groups, set exactly one query type. This surfaces at plan time what the
+// request builders would otherwise only report at apply time. Requests whose
+// query types aren't known yet, e.g. interpolated from another resource, are
+// left to the request builders.
+func getWidgetRequestQueryErrors(prefix string, terraformWidgets []interface{}, isKnown func(string) bool) []string {
+	var errs []string
+	for i, _widget := range terraformWidgets {
+		terraformWidget, ok := _widget.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if _def, ok := terraformWidget["group_definition"].([]interface{}); ok && len(_def) != 0 {
+			if groupDefinition, ok := _def[0].(map[string]interface{}); ok {
+				if v, ok := groupDefinition["widget"].([]interface{}); ok {
+					groupPrefix := fmt.Sprintf("%s.%d.group_definition.0.widget", prefix, i)
+					errs = append(errs, getWidgetRequestQueryErrors(groupPrefix, v, isKnown)...)
+				}
+			}
+			continue
+		}
+		for _, definitionKey := range widgetRequestQueryDefinitions {
+			_def, ok := terraformWidget[definitionKey].([]interface{})
+			if !ok || len(_def) == 0 {
+				continue
+			}
+			terraformDefinition, ok := _def[0].(map[string]interface{})
+			if !ok {
+				continue
+			}
+			terraformRequests, _ := terraformDefinition["request"].([]interface{})
+			for j, _request := range terraformRequests {
+				terraformRequest, ok := _request.(map[string]interface{})
+				if !ok {
+					continue
+				}
+				requestPrefix := fmt.Sprintf("%s.%d.%s.0.request.%d", prefix, i, definitionKey, j)
+				if !isWidgetRequestQueryKnown(requestPrefix, isKnown) {
+					continue
+				}
+				if err := validateWidgetRequestQuery(terraformRequest); err != nil {
+					errs = append(errs, fmt.Sprintf("%s: %s", requestPrefix, err))
+				}
+			}
+		}
+	}
+	return errs
+}
+
+// Helper to check that none of the query types of a widget request are still to be computed
+func isWidgetRequestQueryKnown(requestPrefix string, isKnown func(string) bool) bool {
+	for _, key := range getWidgetRequestQueryTypes() {
+		if !isKnown(fmt.Sprintf("%s.%s", requestPrefix, key)) {
+			return false
+		}
+	}
+	return true
+}
+
 func resourceDatadogDashboardCreate(d *schema.ResourceData, meta interface{}) error {
 	dashboard, err := buildDatadogDashboard(d)
 	if err != nil {
@@ -112,6 +310,9 @@ func resourceDatadogDashboardRead(d *schema.ResourceData, meta interface{}) erro
 	if err = d.Set("is_read_only", dashboard.GetIsReadOnly()); err != nil {
 		return err
 	}
+	if err = d.Set("url", buildDashboardUrl(meta.(*datadog.Client).GetBaseUrl(), dashboard.GetUrl())); err != nil {
+		return err
+	}
 
 	// Set widgets
 	terraformWidgets, err := buildTerraformWidgets(&dashboard.Widgets)
@@ -163,10 +364,31 @@ func resourceDatadogDashboardExists(d *schema.ResourceData, meta interface{}) (b
 	return true, nil
 }
 
+// Helper to turn the dashboard URL returned by the API into a full URL.
+// The API may return a path relative to the web app, e.g. "/dashboard/abc-def-ghi".
+func buildDashboardUrl(apiUrl string, dashboardUrl string) string {
+	if len(dashboardUrl) == 0 || !strings.HasPrefix(dashboardUrl, "/") {
+		return dashboardUrl
+	}
+	u, err := url.Parse(apiUrl)
+	if err != nil || len(u.Host) == 0 {
+		return dashboardUrl
+	}
+	// The web app is served from "app." when the API is served from "api."
+	host := u.Host
+	if strings.HasPrefix(host, "api.") {
+		host = "app." + strings.TrimPrefix(host, "api.")
+	}
+	return fmt.Sprintf("%s://%s%s", u.Scheme, host, dashboardUrl)
+}
+
 func buildDatadogDashboard(d *schema.ResourceData) (*datadog.Board, error) {
 	var dashboard datadog.Board
 
-	dashboard.SetId(d.Id())
+	// The Id is only known on update, it must be omitted on create
+	if id := d.Id(); id != "" {
+		dashboard.SetId(id)
+	}
 
 	if v, ok := d.GetOk("title"); ok {
 		dashboard.SetTitle(v.(string))
@@ -285,226 +507,350 @@ func buildTerraformNotifyList(datadogNotifyList *[]string) *[]string {
 // Widget helpers
 //
 
-// The generic widget schema is a combination of the schema for a non-group widget
-// and the schema for a Group Widget (which can contains only non-group widgets)
-func getWidgetSchema() map[string]*schema.Schema {
-	widgetSchema := getNonGroupWidgetSchema()
-	widgetSchema["group_definition"] = &schema.Schema{
-		Type:        schema.TypeList,
-		Optional:    true,
-		MaxItems:    1,
-		Description: "The definition for a Group widget",
-		Elem: &schema.Resource{
-			Schema: getGroupDefinitionSchema(),
+// widgetDefinition registers the helpers used to manage one type of widget definition
+type widgetDefinition struct {
+	// The key of the definition in a Terraform widget, e.g. "note_definition"
+	definitionKey string
+	description   string
+	// The matching Datadog widget type, e.g. datadog.NOTE_WIDGET
+	widgetType               string
+	getSchema                func() map[string]*schema.Schema
+	buildDatadogDefinition   func(map[string]interface{}) (interface{}, error)
+	buildTerraformDefinition func(interface{}) map[string]interface{}
+}
+
+// Registry of the widget definitions supported in a group widget, in the order they are looked up.
+// Adding support for a new widget only requires a new entry here.
+func getNonGroupWidgetDefinitions() []widgetDefinition {
+	return []widgetDefinition{
+		{
+			definitionKey: "alert_graph_definition",
+			description:   "The definition for an Alert Graph widget",
+			widgetType:    datadog.ALERT_GRAPH_WIDGET,
+			getSchema:     getAlertGraphDefinitionSchema,
+			buildDatadogDefinition: func(terraformDefinition map[string]interface{}) (interface{}, error) {
+				return buildDatadogAlertGraphDefinition(terraformDefinition), nil
+			},
+			buildTerraformDefinition: func(datadogDefinition interface{}) map[string]interface{} {
+				return buildTerraformAlertGraphDefinition(datadogDefinition.(datadog.AlertGraphDefinition))
+			},
 		},
-	}
-	return widgetSchema
-}
-
-func getNonGroupWidgetSchema() map[string]*schema.Schema {
-	return map[string]*schema.Schema{
-		"layout": {
-			Type:        schema.TypeMap,
-			Optional:    true,
-			Description: "The layout of the widget on a 'free' dashboard",
-			Elem: &schema.Resource{
-				Schema: getWidgetLayoutSchema(),
+		{
+			definitionKey: "alert_value_definition",
+			description:   "The definition for an Alert Value widget",
+			widgetType:    datadog.ALERT_VALUE_WIDGET,
+			getSchema:     getAlertValueDefinitionSchema,
+			buildDatadogDefinition: func(terraformDefinition map[string]interface{}) (interface{}, error) {
+				return buildDatadogAlertValueDefinition(terraformDefinition), nil
+			},
+			buildTerraformDefinition: func(datadogDefinition interface{}) map[string]interface{} {
+				return buildTerraformAlertValueDefinition(datadogDefinition.(datadog.AlertValueDefinition))
 			},
 		},
-		// A widget should implement exactly one of the following definitions
-		"alert_graph_definition": {
-			Type:        schema.TypeList,
-			Optional:    true,
-			MaxItems:    1,
-			Description: "The definition for a Alert Graph widget",
-			Elem: &schema.Resource{
-				Schema: getAlertGraphDefinitionSchema(),
+		{
+			definitionKey: "change_definition",
+			description:   "The definition for a Change widget",
+			widgetType:    datadog.CHANGE_WIDGET,
+			getSchema:     getChangeDefinitionSchema,
+			buildDatadogDefinition: func(terraformDefinition map[string]interface{}) (interface{}, error) {
+				return buildDatadogChangeDefinition(terraformDefinition)
+			},
+			buildTerraformDefinition: func(datadogDefinition interface{}) map[string]interface{} {
+				return buildTerraformChangeDefinition(datadogDefinition.(datadog.ChangeDefinition))
 			},
 		},
-		"alert_value_definition": {
-			Type:        schema.TypeList,
-			Optional:    true,
-			MaxItems:    1,
-			Description: "The definition for a Alert Value widget",
-			Elem: &schema.Resource{
-				Schema: getAlertValueDefinitionSchema(),
+		{
+			definitionKey: "check_status_definition",
+			description:   "The definition for a Check Status widget",
+			widgetType:    datadog.CHECK_STATUS_WIDGET,
+			getSchema:     getCheckStatusDefinitionSchema,
+			buildDatadogDefinition: func(terraformDefinition map[string]interface{}) (interface{}, error) {
+				return buildDatadogCheckStatusDefinition(terraformDefinition), nil
+			},
+			buildTerraformDefinition: func(datadogDefinition interface{}) map[string]interface{} {
+				return buildTerraformCheckStatusDefinition(datadogDefinition.(datadog.CheckStatusDefinition))
 			},
 		},
-		"change_definition": {
-			Type:        schema.TypeList,
-			Optional:    true,
-			MaxItems:    1,
-			Description: "The definition for a Change  widget",
-			Elem: &schema.Resource{
-				Schema: getChangeDefinitionSchema(),
+		{
+			definitionKey: "distribution_definition",
+			description:   "The definition for a Distribution widget",
+			widgetType:    datadog.DISTRIBUTION_WIDGET,
+			getSchema:     getDistributionDefinitionSchema,
+			buildDatadogDefinition: func(terraformDefinition map[string]interface{}) (interface{}, error) {
+				return buildDatadogDistributionDefinition(terraformDefinition), nil
+			},
+			buildTerraformDefinition: func(datadogDefinition interface{}) map[string]interface{} {
+				return buildTerraformDistributionDefinition(datadogDefinition.(datadog.DistributionDefinition))
 			},
 		},
-		"check_status_definition": {
-			Type:        schema.TypeList,
-			Optional:    true,
-			MaxItems:    1,
-			Description: "The definition for a Check Status widget",
-			Elem: &schema.Resource{
-				Schema: getCheckStatusDefinitionSchema(),
+		{
+			definitionKey: "event_stream_definition",
+			description:   "The definition for an Event Stream widget",
+			widgetType:    datadog.EVENT_STREAM_WIDGET,
+			getSchema:     getEventStreamDefinitionSchema,
+			buildDatadogDefinition: func(terraformDefinition map[string]interface{}) (interface{}, error) {
+				return buildDatadogEventStreamDefinition(terraformDefinition), nil
+			},
+			buildTerraformDefinition: func(datadogDefinition interface{}) map[string]interface{} {
+				return buildTerraformEventStreamDefinition(datadogDefinition.(datadog.EventStreamDefinition))
 			},
 		},
-		"distribution_definition": {
-			Type:        schema.TypeList,
-			Optional:    true,
-			MaxItems:    1,
-			Description: "The definition for a Distribution widget",
-			Elem: &schema.Resource{
-				Schema: getDistributionDefinitionSchema(),
+		{
+			definitionKey: "event_timeline_definition",
+			description:   "The definition for an Event Timeline widget",
+			widgetType:    datadog.EVENT_TIMELINE_WIDGET,
+			getSchema:     getEventTimelineDefinitionSchema,
+			buildDatadogDefinition: func(terraformDefinition map[string]interface{}) (interface{}, error) {
+				return buildDatadogEventTimelineDefinition(terraformDefinition), nil
+			},
+			buildTerraformDefinition: func(datadogDefinition interface{}) map[string]interface{} {
+				return buildTerraformEventTimelineDefinition(datadogDefinition.(datadog.EventTimelineDefinition))
 			},
 		},
-		"event_stream_definition": {
-			Type:        schema.TypeList,
-			Optional:    true,
-			MaxItems:    1,
-			Description: "The definition for a Event Stream widget",
-			Elem: &schema.Resource{
-				Schema: getEventStreamDefinitionSchema(),
+		{
+			definitionKey: "free_text_definition",
+			description:   "The definition for a Free Text widget",
+			widgetType:    datadog.FREE_TEXT_WIDGET,
+			getSchema:     getFreeTextDefinitionSchema,
+			buildDatadogDefinition: func(terraformDefinition map[string]interface{}) (interface{}, error) {
+				return buildDatadogFreeTextDefinition(terraformDefinition), nil
+			},
+			buildTerraformDefinition: func(datadogDefinition interface{}) map[string]interface{} {
+				return buildTerraformFreeTextDefinition(datadogDefinition.(datadog.FreeTextDefinition))
 			},
 		},
-		"event_timeline_definition": {
-			Type:        schema.TypeList,
-			Optional:    true,
-			MaxItems:    1,
-			Description: "The definition for a Event Timeline widget",
-			Elem: &schema.Resource{
-				Schema: getEventTimelineDefinitionSchema(),
+		{
+			definitionKey: "heatmap_definition",
+			description:   "The definition for a Heatmap widget",
+			widgetType:    datadog.HEATMAP_WIDGET,
+			getSchema:     getHeatmapDefinitionSchema,
+			buildDatadogDefinition: func(terraformDefinition map[string]interface{}) (interface{}, error) {
+				return buildDatadogHeatmapDefinition(terraformDefinition)
+			},
+			buildTerraformDefinition: func(datadogDefinition interface{}) map[string]interface{} {
+				return buildTerraformHeatmapDefinition(datadogDefinition.(datadog.HeatmapDefinition))
 			},
 		},
-		"free_text_definition": {
-			Type:        schema.TypeList,
-			Optional:    true,
-			MaxItems:    1,
-			Description: "The definition for a Free Text widget",
-			Elem: &schema.Resource{
-				Schema: getFreeTextDefinitionSchema(),
+		{
+			definitionKey: "hostmap_definition",
+			description:   "The definition for a Hostmap widget",
+			widgetType:    datadog.HOSTMAP_WIDGET,
+			getSchema:     getHostmapDefinitionSchema,
+			buildDatadogDefinition: func(terraformDefinition map[string]interface{}) (interface{}, error) {
+				return buildDatadogHostmapDefinition(terraformDefinition), nil
+			},
+			buildTerraformDefinition: func(datadogDefinition interface{}) map[string]interface{} {
+				return buildTerraformHostmapDefinition(datadogDefinition.(datadog.HostmapDefinition))
 			},
 		},
-		"heatmap_definition": {
-			Type:        schema.TypeList,
-			Optional:    true,
-			MaxItems:    1,
-			Description: "The definition for a Heatmap widget",
-			Elem: &schema.Resource{
-				Schema: getHeatmapDefinitionSchema(),
+		{
+			definitionKey: "iframe_definition",
+			description:   "The definition for an Iframe widget",
+			widgetType:    datadog.IFRAME_WIDGET,
+			getSchema:     getIframeDefinitionSchema,
+			buildDatadogDefinition: func(terraformDefinition map[string]interface{}) (interface{}, error) {
+				return buildDatadogIframeDefinition(terraformDefinition), nil
+			},
+			buildTerraformDefinition: func(datadogDefinition interface{}) map[string]interface{} {
+				return buildTerraformIframeDefinition(datadogDefinition.(datadog.IframeDefinition))
 			},
 		},
-		"hostmap_definition": {
-			Type:        schema.TypeList,
-			Optional:    true,
-			MaxItems:    1,
-			Description: "The definition for a Hostmap widget",
-			Elem: &schema.Resource{
-				Schema: getHostmapDefinitionSchema(),
+		{
+			definitionKey: "image_definition",
+			description:   "The definition for an Image widget",
+			widgetType:    datadog.IMAGE_WIDGET,
+			getSchema:     getImageDefinitionSchema,
+			buildDatadogDefinition: func(terraformDefinition map[string]interface{}) (interface{}, error) {
+				return buildDatadogImageDefinition(terraformDefinition), nil
+			},
+			buildTerraformDefinition: func(datadogDefinition interface{}) map[string]interface{} {
+				return buildTerraformImageDefinition(datadogDefinition.(datadog.ImageDefinition))
 			},
 		},
-		"iframe_definition": {
-			Type:        schema.TypeList,
-			Optional:    true,
-			MaxItems:    1,
-			Description: "The definition for an Iframe widget",
-			Elem: &schema.Resource{
-				Schema: getIframeDefinitionSchema(),
+		{
+			definitionKey: "log_stream_definition",
+			description:   "The definition for a Log Stream widget",
+			widgetType:    datadog.LOG_STREAM_WIDGET,
+			getSchema:     getLogStreamDefinitionSchema,
+			buildDatadogDefinition: func(terraformDefinition map[string]interface{}) (interface{}, error) {
+				return buildDatadogLogStreamDefinition(terraformDefinition), nil
+			},
+			buildTerraformDefinition: func(datadogDefinition interface{}) map[string]interface{} {
+				return buildTerraformLogStreamDefinition(datadogDefinition.(datadog.LogStreamDefinition))
 			},
 		},
-		"image_definition": {
-			Type:        schema.TypeList,
-			Optional:    true,
-			MaxItems:    1,
-			Description: "The definition for an Image widget",
-			Elem: &schema.Resource{
-				Schema: getImageDefinitionSchema(),
+		{
+			definitionKey: "manage_status_definition",
+			description:   "The definition for a Manage Status widget",
+			widgetType:    datadog.MANAGE_STATUS_WIDGET,
+			getSchema:     getManageStatusDefinitionSchema,
+			buildDatadogDefinition: func(terraformDefinition map[string]interface{}) (interface{}, error) {
+				return buildDatadogManageStatusDefinition(terraformDefinition), nil
+			},
+			buildTerraformDefinition: func(datadogDefinition interface{}) map[string]interface{} {
+				return buildTerraformManageStatusDefinition(datadogDefinition.(datadog.ManageStatusDefinition))
 			},
 		},
-		"log_stream_definition": {
-			Type:        schema.TypeList,
-			Optional:    true,
-			MaxItems:    1,
-			Description: "The definition for an Log Stream widget",
-			Elem: &schema.Resource{
-				Schema: getLogStreamDefinitionSchema(),
+		{
+			definitionKey: "note_definition",
+			description:   "The definition for a Note widget",
+			widgetType:    datadog.NOTE_WIDGET,
+			getSchema:     getNoteDefinitionSchema,
+			buildDatadogDefinition: func(terraformDefinition map[string]interface{}) (interface{}, error) {
+				return buildDatadogNoteDefinition(terraformDefinition), nil
+			},
+			buildTerraformDefinition: func(datadogDefinition interface{}) map[string]interface{} {
+				return buildTerraformNoteDefinition(datadogDefinition.(datadog.NoteDefinition))
 			},
 		},
-		"manage_status_definition": {
-			Type:        schema.TypeList,
-			Optional:    true,
-			MaxItems:    1,
-			Description: "The definition for an Manage Status widget",
-			Elem: &schema.Resource{
-				Schema: getManageStatusDefinitionSchema(),
+		{
+			definitionKey: "query_value_definition",
+			description:   "The definition for a Query Value widget",
+			widgetType:    datadog.QUERY_VALUE_WIDGET,
+			getSchema:     getQueryValueDefinitionSchema,
+			buildDatadogDefinition: func(terraformDefinition map[string]interface{}) (interface{}, error) {
+				return buildDatadogQueryValueDefinition(terraformDefinition)
+			},
+			buildTerraformDefinition: func(datadogDefinition interface{}) map[string]interface{} {
+				return buildTerraformQueryValueDefinition(datadogDefinition.(datadog.QueryValueDefinition))
 			},
 		},
-
-		"note_definition": {
-			Type:        schema.TypeList,
-			Optional:    true,
-			MaxItems:    1,
-			Description: "The definition for a Note widget",
-			Elem: &schema.Resource{
-				Schema: getNoteDefinitionSchema(),
+		{
+			definitionKey: "scatterplot_definition",
+			description:   "The definition for a Scatterplot widget",
+			widgetType:    datadog.SCATTERPLOT_WIDGET,
+			getSchema:     getScatterplotDefinitionSchema,
+			buildDatadogDefinition: func(terraformDefinition map[string]interface{}) (interface{}, error) {
+				return buildDatadogScatterplotDefinition(terraformDefinition), nil
+			},
+			buildTerraformDefinition: func(datadogDefinition interface{}) map[string]interface{} {
+				return buildTerraformScatterplotDefinition(datadogDefinition.(datadog.ScatterplotDefinition))
 			},
 		},
-		"query_value_definition": {
-			Type:        schema.TypeList,
-			Optional:    true,
-			MaxItems:    1,
-			Description: "The definition for a Query Value widget",
-			Elem: &schema.Resource{
-				Schema: getQueryValueDefinitionSchema(),
+		{
+			definitionKey: "timeseries_definition",
+			description:   "The definition for a Timeseries widget",
+			widgetType:    datadog.TIMESERIES_WIDGET,
+			getSchema:     getTimeseriesDefinitionSchema,
+			buildDatadogDefinition: func(terraformDefinition map[string]interface{}) (interface{}, error) {
+				return buildDatadogTimeseriesDefinition(terraformDefinition)
+			},
+			buildTerraformDefinition: func(datadogDefinition interface{}) map[string]interface{} {
+				return buildTerraformTimeseriesDefinition(datadogDefinition.(datadog.TimeseriesDefinition))
 			},
 		},
-		"scatterplot_definition": {
-			Type:        schema.TypeList,
-			Optional:    true,
-			MaxItems:    1,
-			Description: "The definition for a Scatterplot widget",
-			Elem: &schema.Resource{
-				Schema: getScatterplotDefinitionSchema(),
+		{
+			definitionKey: "toplist_definition",
+			description:   "The definition for a Toplist widget",
+			widgetType:    datadog.TOPLIST_WIDGET,
+			getSchema:     getToplistDefinitionSchema,
+			buildDatadogDefinition: func(terraformDefinition map[string]interface{}) (interface{}, error) {
+				return buildDatadogToplistDefinition(terraformDefinition)
+			},
+			buildTerraformDefinition: func(datadogDefinition interface{}) map[string]interface{} {
+				return buildTerraformToplistDefinition(datadogDefinition.(datadog.ToplistDefinition))
 			},
 		},
-		"timeseries_definition": {
-			Type:        schema.TypeList,
-			Optional:    true,
-			MaxItems:    1,
-			Description: "The definition for a Timeseries widget",
-			Elem: &schema.Resource{
-				Schema: getTimeseriesDefinitionSchema(),
+		{
+			definitionKey: "trace_service_definition",
+			description:   "The definition for a Trace Service widget",
+			widgetType:    datadog.TRACE_SERVICE_WIDGET,
+			getSchema:     getTraceServiceDefinitionSchema,
+			buildDatadogDefinition: func(terraformDefinition map[string]interface{}) (interface{}, error) {
+				return buildDatadogTraceServiceDefinition(terraformDefinition), nil
+			},
+			buildTerraformDefinition: func(datadogDefinition interface{}) map[string]interface{} {
+				return buildTerraformTraceServiceDefinition(datadogDefinition.(datadog.TraceServiceDefinition))
 			},
 		},
-		"toplist_definition": {
-			Type:        schema.TypeList,
+	}
+}
+
+// Registry of all the widget definitions supported on a dashboard, in the order they are looked up
+func getWidgetDefinitions() []widgetDefinition {
+	groupWidgetDefinition := widgetDefinition{
+		definitionKey: "group_definition",
+		description:   "The definition for a Group widget",
+		widgetType:    datadog.GROUP_WIDGET,
+		getSchema:     getGroupDefinitionSchema,
+		buildDatadogDefinition: func(terraformDefinition map[string]interface{}) (interface{}, error) {
+			return buildDatadogGroupDefinition(terraformDefinition)
+		},
+		buildTerraformDefinition: func(datadogDefinition interface{}) map[string]interface{} {
+			return buildTerraformGroupDefinition(datadogDefinition.(datadog.GroupDefinition))
+		},
+	}
+	return append([]widgetDefinition{groupWidgetDefinition}, getNonGroupWidgetDefinitions()...)
+}
+
+// The generic widget schema is a combination of the schema for a non-group widget
+// and the schema for a Group Widget (which can contains only non-group widgets)
+func getWidgetSchema() map[string]*schema.Schema {
+	return buildWidgetSchema(getWidgetDefinitions())
+}
+
+func getNonGroupWidgetSchema() map[string]*schema.Schema {
+	return buildWidgetSchema(getNonGroupWidgetDefinitions())
+}
+
+func buildWidgetSchema(widgetDefinitions []widgetDefinition) map[string]*schema.Schema {
+	widgetSchema := map[string]*schema.Schema{
+		"layout": {
+			Type:        schema.TypeMap,
 			Optional:    true,
-			MaxItems:    1,
-			Description: "The definition for a Toplist widget",
+			Description: "The layout of the widget on a 'free' dashboard",
 			Elem: &schema.Resource{
-				Schema: getToplistDefinitionSchema(),
+				Schema: getWidgetLayoutSchema(),
 			},
 		},
-		"trace_service_definition": {
+	}
+	// A widget should implement exactly one of the following definitions
+	for _, definition := range widgetDefinitions {
+		widgetSchema[definition.definitionKey] = &schema.Schema{
 			Type:        schema.TypeList,
 			Optional:    true,
 			MaxItems:    1,
-			Description: "The definition for a Trace Service widget",
+			Description: definition.description,
 			Elem: &schema.Resource{
-				Schema: getTraceServiceDefinitionSchema(),
+				Schema: definition.getSchema(),
 			},
-		},
+		}
 	}
+	return widgetSchema
 }
 
+// widgetErrors lists every widget error of a dashboard, each prefixed with the
+// path of the widget it applies to
+type widgetErrors []string
+
+func (errs widgetErrors) Error() string {
+	return fmt.Sprintf("%d widget error(s): %s", len(errs), strings.Join(errs, "; "))
+}
+
+// Helper to build a list of Datadog widgets from a list of Terraform widgets
 func buildDatadogWidgets(terraformWidgets *[]interface{}) (*[]datadog.BoardWidget, error) {
 	datadogWidgets := make([]datadog.BoardWidget, len(*terraformWidgets))
+	// Collect every invalid widget rather than stopping at the first one so
+	// that large dashboards can be fixed in a single pass.
+	var errs widgetErrors
 	for i, terraformWidget := range *terraformWidgets {
 		datadogWidget, err := buildDatadogWidget(terraformWidget.(map[string]interface{}))
+		if groupErrs, ok := err.(widgetErrors); ok {
+			// Errors of widgets nested in a group are flattened into the same list
+			for _, groupErr := range groupErrs {
+				errs = append(errs, fmt.Sprintf("widget.%d.group_definition.0.%s", i, groupErr))
+			}
+			continue
+		}
 		if err != nil {
-			return nil, err
+			errs = append(errs, fmt.Sprintf("widget.%d: %s", i, err.Error()))
+			continue
 		}
 		datadogWidgets[i] = *datadogWidget
 	}
+	if len(errs) > 0 {
+		return nil, errs
+	}
 	return &datadogWidgets, nil
 }
 
@@ -518,99 +864,22 @@ func buildDatadogWidget(terraformWidget map[string]interface{}) (*datadog.BoardW
 	}
 
 	// Build widget Definition
-	if _def, ok := terraformWidget["group_definition"].([]interface{}); ok && len(_def) > 0 {
-		if groupDefinition, ok := _def[0].(map[string]interface{}); ok {
-			datadogDefinition, err := buildDatadogGroupDefinition(groupDefinition)
+	for _, definition := range getWidgetDefinitions() {
+		_def, ok := terraformWidget[definition.definitionKey].([]interface{})
+		if !ok || len(_def) == 0 {
+			continue
+		}
+		if terraformDefinition, ok := _def[0].(map[string]interface{}); ok {
+			datadogDefinition, err := definition.buildDatadogDefinition(terraformDefinition)
 			if err != nil {
 				return nil, err
 			}
 			datadogWidget.Definition = datadogDefinition
 		}
-	} else if _def, ok := terraformWidget["alert_graph_definition"].([]interface{}); ok && len(_def) > 0 {
-		if alertGraphDefinition, ok := _def[0].(map[string]interface{}); ok {
-			datadogWidget.Definition = buildDatadogAlertGraphDefinition(alertGraphDefinition)
-		}
-	} else if _def, ok := terraformWidget["alert_value_definition"].([]interface{}); ok && len(_def) > 0 {
-		if alertValueDefinition, ok := _def[0].(map[string]interface{}); ok {
-			datadogWidget.Definition = buildDatadogAlertValueDefinition(alertValueDefinition)
-		}
-	} else if _def, ok := terraformWidget["change_definition"].([]interface{}); ok && len(_def) > 0 {
-		if changeDefinition, ok := _def[0].(map[string]interface{}); ok {
-			datadogWidget.Definition = buildDatadogChangeDefinition(changeDefinition)
-		}
-	} else if _def, ok := terraformWidget["check_status_definition"].([]interface{}); ok && len(_def) > 0 {
-		if checkStatusDefinition, ok := _def[0].(map[string]interface{}); ok {
-			datadogWidget.Definition = buildDatadogCheckStatusDefinition(checkStatusDefinition)
-		}
-	} else if _def, ok := terraformWidget["distribution_definition"].([]interface{}); ok && len(_def) > 0 {
-		if distributionDefinition, ok := _def[0].(map[string]interface{}); ok {
-			datadogWidget.Definition = buildDatadogDistributionDefinition(distributionDefinition)
-		}
-	} else if _def, ok := terraformWidget["event_stream_definition"].([]interface{}); ok && len(_def) > 0 {
-		if eventStreamDefinition, ok := _def[0].(map[string]interface{}); ok {
-			datadogWidget.Definition = buildDatadogEventStreamDefinition(eventStreamDefinition)
-		}
-	} else if _def, ok := terraformWidget["event_timeline_definition"].([]interface{}); ok && len(_def) > 0 {
-		if eventTimelineDefinition, ok := _def[0].(map[string]interface{}); ok {
-			datadogWidget.Definition = buildDatadogEventTimelineDefinition(eventTimelineDefinition)
-		}
-	} else if _def, ok := terraformWidget["free_text_definition"].([]interface{}); ok && len(_def) > 0 {
-		if freeTextDefinition, ok := _def[0].(map[string]interface{}); ok {
-			datadogWidget.Definition = buildDatadogFreeTextDefinition(freeTextDefinition)
-		}
-	} else if _def, ok := terraformWidget["heatmap_definition"].([]interface{}); ok && len(_def) > 0 {
-		if heatmapDefinition, ok := _def[0].(map[string]interface{}); ok {
-			datadogWidget.Definition = buildDatadogHeatmapDefinition(heatmapDefinition)
-		}
-	} else if _def, ok := terraformWidget["hostmap_definition"].([]interface{}); ok && len(_def) > 0 {
-		if hostDefinition, ok := _def[0].(map[string]interface{}); ok {
-			datadogWidget.Definition = buildDatadogHostmapDefinition(hostDefinition)
-		}
-	} else if _def, ok := terraformWidget["iframe_definition"].([]interface{}); ok && len(_def) > 0 {
-		if iframeDefinition, ok := _def[0].(map[string]interface{}); ok {
-			datadogWidget.Definition = buildDatadogIframeDefinition(iframeDefinition)
-		}
-	} else if _def, ok := terraformWidget["image_definition"].([]interface{}); ok && len(_def) > 0 {
-		if imageDefinition, ok := _def[0].(map[string]interface{}); ok {
-			datadogWidget.Definition = buildDatadogImageDefinition(imageDefinition)
-		}
-	} else if _def, ok := terraformWidget["log_stream_definition"].([]interface{}); ok && len(_def) > 0 {
-		if logStreamDefinition, ok := _def[0].(map[string]interface{}); ok {
-			datadogWidget.Definition = buildDatadogLogStreamDefinition(logStreamDefinition)
-		}
-	} else if _def, ok := terraformWidget["manage_status_definition"].([]interface{}); ok && len(_def) > 0 {
-		if manageStatusDefinition, ok := _def[0].(map[string]interface{}); ok {
-			datadogWidget.Definition = buildDatadogManageStatusDefinition(manageStatusDefinition)
-		}
-	} else if _def, ok := terraformWidget["note_definition"].([]interface{}); ok && len(_def) > 0 {
-		if noteDefinition, ok := _def[0].(map[string]interface{}); ok {
-			datadogWidget.Definition = buildDatadogNoteDefinition(noteDefinition)
-		}
-	} else if _def, ok := terraformWidget["query_value_definition"].([]interface{}); ok && len(_def) > 0 {
-		if queryValueDefinition, ok := _def[0].(map[string]interface{}); ok {
-			datadogWidget.Definition = buildDatadogQueryValueDefinition(queryValueDefinition)
-		}
-	} else if _def, ok := terraformWidget["scatterplot_definition"].([]interface{}); ok && len(_def) > 0 {
-		if scatterplotDefinition, ok := _def[0].(map[string]interface{}); ok {
-			datadogWidget.Definition = buildDatadogScatterplotDefinition(scatterplotDefinition)
-		}
-	} else if _def, ok := terraformWidget["timeseries_definition"].([]interface{}); ok && len(_def) > 0 {
-		if timeseriesDefinition, ok := _def[0].(map[string]interface{}); ok {
-			datadogWidget.Definition = buildDatadogTimeseriesDefinition(timeseriesDefinition)
-		}
-	} else if _def, ok := terraformWidget["toplist_definition"].([]interface{}); ok && len(_def) > 0 {
-		if toplistDefinition, ok := _def[0].(map[string]interface{}); ok {
-			datadogWidget.Definition = buildDatadogToplistDefinition(toplistDefinition)
-		}
-	} else if _def, ok := terraformWidget["trace_service_definition"].([]interface{}); ok && len(_def) > 0 {
-		if traceServiceDefinition, ok := _def[0].(map[string]interface{}); ok {
-			datadogWidget.Definition = buildDatadogTraceServiceDefinition(traceServiceDefinition)
-		}
-	} else {
-		return nil, fmt.Errorf("Failed to find valid definition in widget configuration")
+		return &datadogWidget, nil
 	}
 
-	return &datadogWidget, nil
+	return nil, fmt.Errorf("Failed to find valid definition in widget configuration")
 }
 
 // Helper to build a list of Terraform widgets from a list of Datadog widgets
@@ -640,96 +909,15 @@ func buildTerraformWidget(datadogWidget datadog.BoardWidget) (map[string]interfa
 	if err != nil {
 		return nil, err
 	}
-	switch widgetType {
-	case datadog.GROUP_WIDGET:
-		datadogDefinition := datadogWidget.Definition.(datadog.GroupDefinition)
-		terraformDefinition := buildTerraformGroupDefinition(datadogDefinition)
-		terraformWidget["group_definition"] = []map[string]interface{}{terraformDefinition}
-	case datadog.ALERT_GRAPH_WIDGET:
-		datadogDefinition := datadogWidget.Definition.(datadog.AlertGraphDefinition)
-		terraformDefinition := buildTerraformAlertGraphDefinition(datadogDefinition)
-		terraformWidget["alert_graph_definition"] = []map[string]interface{}{terraformDefinition}
-	case datadog.ALERT_VALUE_WIDGET:
-		datadogDefinition := datadogWidget.Definition.(datadog.AlertValueDefinition)
-		terraformDefinition := buildTerraformAlertValueDefinition(datadogDefinition)
-		terraformWidget["alert_value_definition"] = []map[string]interface{}{terraformDefinition}
-	case datadog.CHANGE_WIDGET:
-		datadogDefinition := datadogWidget.Definition.(datadog.ChangeDefinition)
-		terraformDefinition := buildTerraformChangeDefinition(datadogDefinition)
-		terraformWidget["change_definition"] = []map[string]interface{}{terraformDefinition}
-	case datadog.CHECK_STATUS_WIDGET:
-		datadogDefinition := datadogWidget.Definition.(datadog.CheckStatusDefinition)
-		terraformDefinition := buildTerraformCheckStatusDefinition(datadogDefinition)
-		terraformWidget["check_status_definition"] = []map[string]interface{}{terraformDefinition}
-	case datadog.DISTRIBUTION_WIDGET:
-		datadogDefinition := datadogWidget.Definition.(datadog.DistributionDefinition)
-		terraformDefinition := buildTerraformDistributionDefinition(datadogDefinition)
-		terraformWidget["distribution_definition"] = []map[string]interface{}{terraformDefinition}
-	case datadog.EVENT_STREAM_WIDGET:
-		datadogDefinition := datadogWidget.Definition.(datadog.EventStreamDefinition)
-		terraformDefinition := buildTerraformEventStreamDefinition(datadogDefinition)
-		terraformWidget["event_stream_definition"] = []map[string]interface{}{terraformDefinition}
-	case datadog.EVENT_TIMELINE_WIDGET:
-		datadogDefinition := datadogWidget.Definition.(datadog.EventTimelineDefinition)
-		terraformDefinition := buildTerraformEventTimelineDefinition(datadogDefinition)
-		terraformWidget["event_timeline_definition"] = []map[string]interface{}{terraformDefinition}
-	case datadog.FREE_TEXT_WIDGET:
-		datadogDefinition := datadogWidget.Definition.(datadog.FreeTextDefinition)
-		terraformDefinition := buildTerraformFreeTextDefinition(datadogDefinition)
-		terraformWidget["free_text_definition"] = []map[string]interface{}{terraformDefinition}
-	case datadog.HEATMAP_WIDGET:
-		datadogDefinition := datadogWidget.Definition.(datadog.HeatmapDefinition)
-		terraformDefinition := buildTerraformHeatmapDefinition(datadogDefinition)
-		terraformWidget["heatmap_definition"] = []map[string]interface{}{terraformDefinition}
-	case datadog.HOSTMAP_WIDGET:
-		datadogDefinition := datadogWidget.Definition.(datadog.HostmapDefinition)
-		terraformDefinition := buildTerraformHostmapDefinition(datadogDefinition)
-		terraformWidget["hostmap_definition"] = []map[string]interface{}{terraformDefinition}
-	case datadog.IFRAME_WIDGET:
-		datadogDefinition := datadogWidget.Definition.(datadog.IframeDefinition)
-		terraformDefinition := buildTerraformIframeDefinition(datadogDefinition)
-		terraformWidget["iframe_definition"] = []map[string]interface{}{terraformDefinition}
-	case datadog.IMAGE_WIDGET:
-		datadogDefinition := datadogWidget.Definition.(datadog.ImageDefinition)
-		terraformDefinition := buildTerraformImageDefinition(datadogDefinition)
-		terraformWidget["image_definition"] = []map[string]interface{}{terraformDefinition}
-	case datadog.LOG_STREAM_WIDGET:
-		datadogDefinition := datadogWidget.Definition.(datadog.LogStreamDefinition)
-		terraformDefinition := buildTerraformLogStreamDefinition(datadogDefinition)
-		terraformWidget["log_stream_definition"] = []map[string]interface{}{terraformDefinition}
-	case datadog.MANAGE_STATUS_WIDGET:
-		datadogDefinition := datadogWidget.Definition.(datadog.ManageStatusDefinition)
-		terraformDefinition := buildTerraformManageStatusDefinition(datadogDefinition)
-		terraformWidget["manage_status_definition"] = []map[string]interface{}{terraformDefinition}
-	case datadog.NOTE_WIDGET:
-		datadogDefinition := datadogWidget.Definition.(datadog.NoteDefinition)
-		terraformDefinition := buildTerraformNoteDefinition(datadogDefinition)
-		terraformWidget["note_definition"] = []map[string]interface{}{terraformDefinition}
-	case datadog.QUERY_VALUE_WIDGET:
-		datadogDefinition := datadogWidget.Definition.(datadog.QueryValueDefinition)
-		terraformDefinition := buildTerraformQueryValueDefinition(datadogDefinition)
-		terraformWidget["query_value_definition"] = []map[string]interface{}{terraformDefinition}
-	case datadog.SCATTERPLOT_WIDGET:
-		datadogDefinition := datadogWidget.Definition.(datadog.ScatterplotDefinition)
-		terraformDefinition := buildTerraformScatterplotDefinition(datadogDefinition)
-		terraformWidget["scatterplot_definition"] = []map[string]interface{}{terraformDefinition}
-	case datadog.TIMESERIES_WIDGET:
-		datadogDefinition := datadogWidget.Definition.(datadog.TimeseriesDefinition)
-		terraformDefinition := buildTerraformTimeseriesDefinition(datadogDefinition)
-		terraformWidget["timeseries_definition"] = []map[string]interface{}{terraformDefinition}
-	case datadog.TOPLIST_WIDGET:
-		datadogDefinition := datadogWidget.Definition.(datadog.ToplistDefinition)
-		terraformDefinition := buildTerraformToplistDefinition(datadogDefinition)
-		terraformWidget["toplist_definition"] = []map[string]interface{}{terraformDefinition}
-	case datadog.TRACE_SERVICE_WIDGET:
-		datadogDefinition := datadogWidget.Definition.(datadog.TraceServiceDefinition)
-		terraformDefinition := buildTerraformTraceServiceDefinition(datadogDefinition)
-		terraformWidget["trace_service_definition"] = []map[string]interface{}{terraformDefinition}
-	default:
-		return nil, fmt.Errorf("Unsupported widget type: %s - %s", widgetType, datadog.TIMESERIES_WIDGET)
+	for _, definition := range getWidgetDefinitions() {
+		if definition.widgetType == widgetType {
+			terraformDefinition := definition.buildTerraformDefinition(datadogWidget.Definition)
+			terraformWidget[definition.definitionKey] = []map[string]interface{}{terraformDefinition}
+			return terraformWidget, nil
+		}
 	}
 
-	return terraformWidget, nil
+	return nil, fmt.Errorf("Unsupported widget type: %s", widgetType)
 }
 
 //
@@ -860,8 +1048,11 @@ func buildTerraformGroupDefinition(datadogGroupDefinition datadog.GroupDefinitio
 	}
 	terraformGroupDefinition["widget"] = groupWidgets
 
+	// The API may omit the group layout, fall back to "ordered", the only supported value
 	if v, ok := datadogGroupDefinition.GetLayoutTypeOk(); ok {
 		terraformGroupDefinition["layout_type"] = v
+	} else {
+		terraformGroupDefinition["layout_type"] = "ordered"
 	}
 	if v, ok := datadogGroupDefinition.GetTitleOk(); ok {
 		terraformGroupDefinition["title"] = v
@@ -889,8 +1080,9 @@ func getAlertGraphDefinitionSchema() map[string]*schema.Schema {
 			Optional: true,
 		},
 		"title_size": {
-			Type:     schema.TypeString,
-			Optional: true,
+			Type:             schema.TypeString,
+			Optional:         true,
+			DiffSuppressFunc: suppressTitleSizeDiff,
 		},
 		"title_align": {
 			Type:     schema.TypeString,
@@ -968,16 +1160,18 @@ func getAlertValueDefinitionSchema() map[string]*schema.Schema {
 			Optional: true,
 		},
 		"text_align": {
-			Type:     schema.TypeString,
-			Optional: true,
+			Type:         schema.TypeString,
+			Optional:     true,
+			ValidateFunc: validateWidgetTextAlign,
 		},
 		"title": {
 			Type:     schema.TypeString,
 			Optional: true,
 		},
 		"title_size": {
-			Type:     schema.TypeString,
-			Optional: true,
+			Type:             schema.TypeString,
+			Optional:         true,
+			DiffSuppressFunc: suppressTitleSizeDiff,
 		},
 		"title_align": {
 			Type:     schema.TypeString,
@@ -1057,8 +1251,9 @@ func getChangeDefinitionSchema() map[string]*schema.Schema {
 			Optional: true,
 		},
 		"title_size": {
-			Type:     schema.TypeString,
-			Optional: true,
+			Type:             schema.TypeString,
+			Optional:         true,
+			DiffSuppressFunc: suppressTitleSizeDiff,
 		},
 		"title_align": {
 			Type:     schema.TypeString,
@@ -1073,12 +1268,16 @@ func getChangeDefinitionSchema() map[string]*schema.Schema {
 		},
 	}
 }
-func buildDatadogChangeDefinition(terraformDefinition map[string]interface{}) *datadog.ChangeDefinition {
+func buildDatadogChangeDefinition(terraformDefinition map[string]interface{}) (*datadog.ChangeDefinition, error) {
 	datadogDefinition := &datadog.ChangeDefinition{}
 	// Required params
 	datadogDefinition.SetType(datadog.CHANGE_WIDGET)
 	terraformRequests := terraformDefinition["request"].([]interface{})
-	datadogDefinition.Requests = *buildDatadogChangeRequests(&terraformRequests)
+	datadogRequests, err := buildDatadogChangeRequests(&terraformRequests)
+	if err != nil {
+		return nil, err
+	}
+	datadogDefinition.Requests = *datadogRequests
 	// Optional params
 	if v, ok := terraformDefinition["title"].(string); ok && len(v) != 0 {
 		datadogDefinition.SetTitle(v)
@@ -1092,7 +1291,7 @@ func buildDatadogChangeDefinition(terraformDefinition map[string]interface{}) *d
 	if v, ok := terraformDefinition["time"].(map[string]interface{}); ok && len(v) > 0 {
 		datadogDefinition.SetTime(*buildDatadogWidgetTime(v))
 	}
-	return datadogDefinition
+	return datadogDefinition, nil
 }
 func buildTerraformChangeDefinition(datadogDefinition datadog.ChangeDefinition) map[string]interface{} {
 	terraformDefinition := map[string]interface{}{}
@@ -1115,16 +1314,12 @@ func buildTerraformChangeDefinition(datadogDefinition datadog.ChangeDefinition)
 }
 
 func getChangeRequestSchema() map[string]*schema.Schema {
-	return map[string]*schema.Schema{
-		// A request should implement exactly one of the following type of query
-		"q":             getMetricQuerySchema(),
-		"apm_query":     getApmOrLogQuerySchema(),
-		"log_query":     getApmOrLogQuerySchema(),
-		"process_query": getProcessQuerySchema(),
+	requestSchema := map[string]*schema.Schema{
 		// Settings specific to Change requests
 		"change_type": {
-			Type:     schema.TypeString,
-			Optional: true,
+			Type:         schema.TypeString,
+			Optional:     true,
+			ValidateFunc: validateChangeType,
 		},
 		"compare_to": {
 			Type:     schema.TypeString,
@@ -1145,13 +1340,22 @@ func getChangeRequestSchema() map[string]*schema.Schema {
 		"show_present": {
 			Type:     schema.TypeBool,
 			Optional: true,
+			Default:  false,
 		},
 	}
+	// A request should implement exactly one of the query types
+	for key, querySchema := range getWidgetRequestQuerySchema() {
+		requestSchema[key] = querySchema
+	}
+	return requestSchema
 }
-func buildDatadogChangeRequests(terraformRequests *[]interface{}) *[]datadog.ChangeRequest {
+func buildDatadogChangeRequests(terraformRequests *[]interface{}) (*[]datadog.ChangeRequest, error) {
 	datadogRequests := make([]datadog.ChangeRequest, len(*terraformRequests))
 	for i, _request := range *terraformRequests {
 		terraformRequest := _request.(map[string]interface{})
+		if err := validateWidgetRequestQuery(terraformRequest); err != nil {
+			return nil, fmt.Errorf("request.%d: %s", i, err)
+		}
 		// Build ChangeRequest
 		datadogChangeRequest := datadog.ChangeRequest{}
 		if v, ok := terraformRequest["q"].(string); ok && len(v) != 0 {
@@ -1188,7 +1392,7 @@ func buildDatadogChangeRequests(terraformRequests *[]interface{}) *[]datadog.Cha
 
 		datadogRequests[i] = datadogChangeRequest
 	}
-	return &datadogRequests
+	return &datadogRequests, nil
 }
 func buildTerraformChangeRequests(datadogChangeRequests *[]datadog.ChangeRequest) *[]map[string]interface{} {
 	terraformRequests := make([]map[string]interface{}, len(*datadogChangeRequests))
@@ -1222,9 +1426,8 @@ func buildTerraformChangeRequests(datadogChangeRequests *[]datadog.ChangeRequest
 		if datadogRequest.OrderDir != nil {
 			terraformRequest["order_dir"] = *datadogRequest.OrderDir
 		}
-		if datadogRequest.ShowPresent != nil {
-			terraformRequest["show_present"] = *datadogRequest.ShowPresent
-		}
+		// show_present defaults to false server-side and may be omitted from the response
+		terraformRequest["show_present"] = datadogRequest.GetShowPresent()
 		terraformRequests[i] = terraformRequest
 	}
 	return &terraformRequests
@@ -1248,8 +1451,9 @@ func getDistributionDefinitionSchema() map[string]*schema.Schema {
 			Optional: true,
 		},
 		"title_size": {
-			Type:     schema.TypeString,
-			Optional: true,
+			Type:             schema.TypeString,
+			Optional:         true,
+			DiffSuppressFunc: suppressTitleSizeDiff,
 		},
 		"title_align": {
 			Type:     schema.TypeString,
@@ -1306,12 +1510,7 @@ func buildTerraformDistributionDefinition(datadogDefinition datadog.Distribution
 }
 
 func getDistributionRequestSchema() map[string]*schema.Schema {
-	return map[string]*schema.Schema{
-		// A request should implement exactly one of the following type of query
-		"q":             getMetricQuerySchema(),
-		"apm_query":     getApmOrLogQuerySchema(),
-		"log_query":     getApmOrLogQuerySchema(),
-		"process_query": getProcessQuerySchema(),
+	requestSchema := map[string]*schema.Schema{
 		// Settings specific to Distribution requests
 		"style": {
 			Type:     schema.TypeList,
@@ -1322,6 +1521,11 @@ func getDistributionRequestSchema() map[string]*schema.Schema {
 			},
 		},
 	}
+	// A request should implement exactly one of the query types
+	for key, querySchema := range getWidgetRequestQuerySchema() {
+		requestSchema[key] = querySchema
+	}
+	return requestSchema
 }
 func buildDatadogDistributionRequests(terraformRequests *[]interface{}) *[]datadog.DistributionRequest {
 	datadogRequests := make([]datadog.DistributionRequest, len(*terraformRequests))
@@ -1387,16 +1591,18 @@ func getEventStreamDefinitionSchema() map[string]*schema.Schema {
 			Required: true,
 		},
 		"event_size": {
-			Type:     schema.TypeString,
-			Optional: true,
+			Type:         schema.TypeString,
+			Optional:     true,
+			ValidateFunc: validateEventStreamEventSize,
 		},
 		"title": {
 			Type:     schema.TypeString,
 			Optional: true,
 		},
 		"title_size": {
-			Type:     schema.TypeString,
-			Optional: true,
+			Type:             schema.TypeString,
+			Optional:         true,
+			DiffSuppressFunc: suppressTitleSizeDiff,
 		},
 		"title_align": {
 			Type:     schema.TypeString,
@@ -1474,8 +1680,9 @@ func getEventTimelineDefinitionSchema() map[string]*schema.Schema {
 			Optional: true,
 		},
 		"title_size": {
-			Type:     schema.TypeString,
-			Optional: true,
+			Type:             schema.TypeString,
+			Optional:         true,
+			DiffSuppressFunc: suppressTitleSizeDiff,
 		},
 		"title_align": {
 			Type:     schema.TypeString,
@@ -1565,8 +1772,9 @@ func getCheckStatusDefinitionSchema() map[string]*schema.Schema {
 			Optional: true,
 		},
 		"title_size": {
-			Type:     schema.TypeString,
-			Optional: true,
+			Type:             schema.TypeString,
+			Optional:         true,
+			DiffSuppressFunc: suppressTitleSizeDiff,
 		},
 		"title_align": {
 			Type:     schema.TypeString,
@@ -1670,16 +1878,18 @@ func getFreeTextDefinitionSchema() map[string]*schema.Schema {
 			Required: true,
 		},
 		"color": {
-			Type:     schema.TypeString,
-			Optional: true,
+			Type:         schema.TypeString,
+			Optional:     true,
+			ValidateFunc: validateWidgetColor,
 		},
 		"font_size": {
 			Type:     schema.TypeString,
 			Optional: true,
 		},
 		"text_align": {
-			Type:     schema.TypeString,
-			Optional: true,
+			Type:         schema.TypeString,
+			Optional:     true,
+			ValidateFunc: validateWidgetTextAlign,
 		},
 	}
 }
@@ -1745,8 +1955,9 @@ func getHeatmapDefinitionSchema() map[string]*schema.Schema {
 			Optional: true,
 		},
 		"title_size": {
-			Type:     schema.TypeString,
-			Optional: true,
+			Type:             schema.TypeString,
+			Optional:         true,
+			DiffSuppressFunc: suppressTitleSizeDiff,
 		},
 		"title_align": {
 			Type:     schema.TypeString,
@@ -1761,12 +1972,16 @@ func getHeatmapDefinitionSchema() map[string]*schema.Schema {
 		},
 	}
 }
-func buildDatadogHeatmapDefinition(terraformDefinition map[string]interface{}) *datadog.HeatmapDefinition {
+func buildDatadogHeatmapDefinition(terraformDefinition map[string]interface{}) (*datadog.HeatmapDefinition, error) {
 	datadogDefinition := &datadog.HeatmapDefinition{}
 	// Required params
 	datadogDefinition.SetType(datadog.HEATMAP_WIDGET)
 	terraformRequests := terraformDefinition["request"].([]interface{})
-	datadogDefinition.Requests = *buildDatadogHeatmapRequests(&terraformRequests)
+	datadogRequests, err := buildDatadogHeatmapRequests(&terraformRequests)
+	if err != nil {
+		return nil, err
+	}
+	datadogDefinition.Requests = *datadogRequests
 	// Optional params
 	if _axis, ok := terraformDefinition["yaxis"].([]interface{}); ok && len(_axis) > 0 {
 		if v, ok := _axis[0].(map[string]interface{}); ok && len(v) > 0 {
@@ -1785,7 +2000,7 @@ func buildDatadogHeatmapDefinition(terraformDefinition map[string]interface{}) *
 	if v, ok := terraformDefinition["time"].(map[string]interface{}); ok && len(v) > 0 {
 		datadogDefinition.Time = buildDatadogWidgetTime(v)
 	}
-	return datadogDefinition
+	return datadogDefinition, nil
 }
 func buildTerraformHeatmapDefinition(datadogDefinition datadog.HeatmapDefinition) map[string]interface{} {
 	terraformDefinition := map[string]interface{}{}
@@ -1812,12 +2027,7 @@ func buildTerraformHeatmapDefinition(datadogDefinition datadog.HeatmapDefinition
 }
 
 func getHeatmapRequestSchema() map[string]*schema.Schema {
-	return map[string]*schema.Schema{
-		// A request should implement exactly one of the following type of query
-		"q":             getMetricQuerySchema(),
-		"apm_query":     getApmOrLogQuerySchema(),
-		"log_query":     getApmOrLogQuerySchema(),
-		"process_query": getProcessQuerySchema(),
+	requestSchema := map[string]*schema.Schema{
 		// Settings specific to Heatmap requests
 		"style": {
 			Type:     schema.TypeList,
@@ -1828,11 +2038,19 @@ func getHeatmapRequestSchema() map[string]*schema.Schema {
 			},
 		},
 	}
+	// A request should implement exactly one of the query types
+	for key, querySchema := range getWidgetRequestQuerySchema() {
+		requestSchema[key] = querySchema
+	}
+	return requestSchema
 }
-func buildDatadogHeatmapRequests(terraformRequests *[]interface{}) *[]datadog.HeatmapRequest {
+func buildDatadogHeatmapRequests(terraformRequests *[]interface{}) (*[]datadog.HeatmapRequest, error) {
 	datadogRequests := make([]datadog.HeatmapRequest, len(*terraformRequests))
 	for i, _request := range *terraformRequests {
 		terraformRequest := _request.(map[string]interface{})
+		if err := validateWidgetRequestQuery(terraformRequest); err != nil {
+			return nil, fmt.Errorf("request.%d: %s", i, err)
+		}
 		// Build HeatmapRequest
 		datadogHeatmapRequest := datadog.HeatmapRequest{}
 		if v, ok := terraformRequest["q"].(string); ok && len(v) != 0 {
@@ -1854,7 +2072,7 @@ func buildDatadogHeatmapRequests(terraformRequests *[]interface{}) *[]datadog.He
 		}
 		datadogRequests[i] = datadogHeatmapRequest
 	}
-	return &datadogRequests
+	return &datadogRequests, nil
 }
 func buildTerraformHeatmapRequests(datadogHeatmapRequests *[]datadog.HeatmapRequest) *[]map[string]interface{} {
 	terraformRequests := make([]map[string]interface{}, len(*datadogHeatmapRequests))
@@ -1912,8 +2130,9 @@ func getHostmapDefinitionSchema() map[string]*schema.Schema {
 			},
 		},
 		"node_type": {
-			Type:     schema.TypeString,
-			Optional: true,
+			Type:         schema.TypeString,
+			Optional:     true,
+			ValidateFunc: validateHostmapNodeType,
 		},
 		"no_metric_hosts": {
 			Type:     schema.TypeBool,
@@ -1963,8 +2182,9 @@ func getHostmapDefinitionSchema() map[string]*schema.Schema {
 			Optional: true,
 		},
 		"title_size": {
-			Type:     schema.TypeString,
-			Optional: true,
+			Type:             schema.TypeString,
+			Optional:         true,
+			DiffSuppressFunc: suppressTitleSizeDiff,
 		},
 		"title_align": {
 			Type:     schema.TypeString,
@@ -1980,13 +2200,17 @@ func buildDatadogHostmapDefinition(terraformDefinition map[string]interface{}) *
 	if v, ok := terraformDefinition["request"].([]interface{}); ok && len(v) > 0 {
 		terraformRequests := v[0].(map[string]interface{})
 		datadogRequests := datadog.HostmapRequests{}
+		// Only send the fill and size requests that set a query, an empty
+		// query object would otherwise be read back and show up as a diff
 		if terraformFillArray, ok := terraformRequests["fill"].([]interface{}); ok && len(terraformFillArray) > 0 {
-			terraformFill := terraformFillArray[0].(map[string]interface{})
-			datadogRequests.Fill = buildDatadogHostmapRequest(terraformFill)
+			if terraformFill, ok := terraformFillArray[0].(map[string]interface{}); ok && len(getWidgetRequestQueryKeys(terraformFill)) > 0 {
+				datadogRequests.Fill = buildDatadogHostmapRequest(terraformFill)
+			}
 		}
 		if terraformSizeArray, ok := terraformRequests["size"].([]interface{}); ok && len(terraformSizeArray) > 0 {
-			terraformSize := terraformSizeArray[0].(map[string]interface{})
-			datadogRequests.Size = buildDatadogHostmapRequest(terraformSize)
+			if terraformSize, ok := terraformSizeArray[0].(map[string]interface{}); ok && len(getWidgetRequestQueryKeys(terraformSize)) > 0 {
+				datadogRequests.Size = buildDatadogHostmapRequest(terraformSize)
+			}
 		}
 		datadogDefinition.SetRequests(datadogRequests)
 	}
@@ -2036,12 +2260,14 @@ func buildTerraformHostmapDefinition(datadogDefinition datadog.HostmapDefinition
 	// Required params
 	terraformRequests := map[string]interface{}{}
 	if datadogDefinition.Requests.Size != nil {
-		terraformSize := buildTerraformHostmapRequest(datadogDefinition.Requests.Size)
-		terraformRequests["size"] = []map[string]interface{}{*terraformSize}
+		if terraformSize := buildTerraformHostmapRequest(datadogDefinition.Requests.Size); len(*terraformSize) > 0 {
+			terraformRequests["size"] = []map[string]interface{}{*terraformSize}
+		}
 	}
 	if datadogDefinition.Requests.Fill != nil {
-		terraformFill := buildTerraformHostmapRequest(datadogDefinition.Requests.Fill)
-		terraformRequests["fill"] = []map[string]interface{}{*terraformFill}
+		if terraformFill := buildTerraformHostmapRequest(datadogDefinition.Requests.Fill); len(*terraformFill) > 0 {
+			terraformRequests["fill"] = []map[string]interface{}{*terraformFill}
+		}
 	}
 	terraformDefinition["request"] = []map[string]interface{}{terraformRequests}
 	// Optional params
@@ -2085,13 +2311,7 @@ func buildTerraformHostmapDefinition(datadogDefinition datadog.HostmapDefinition
 }
 
 func getHostmapRequestSchema() map[string]*schema.Schema {
-	return map[string]*schema.Schema{
-		// A request should implement at least one of the following type of query
-		"q":             getMetricQuerySchema(),
-		"apm_query":     getApmOrLogQuerySchema(),
-		"log_query":     getApmOrLogQuerySchema(),
-		"process_query": getProcessQuerySchema(),
-	}
+	return getWidgetRequestQuerySchema()
 }
 func buildDatadogHostmapRequest(terraformRequest map[string]interface{}) *datadog.HostmapRequest {
 
@@ -2135,8 +2355,9 @@ func buildTerraformHostmapRequest(datadogHostmapRequest *datadog.HostmapRequest)
 func getIframeDefinitionSchema() map[string]*schema.Schema {
 	return map[string]*schema.Schema{
 		"url": {
-			Type:     schema.TypeString,
-			Required: true,
+			Type:         schema.TypeString,
+			Required:     true,
+			ValidateFunc: validateAbsoluteUrl,
 		},
 	}
 }
@@ -2220,9 +2441,11 @@ func getLogStreamDefinitionSchema() map[string]*schema.Schema {
 			Type:     schema.TypeString,
 			Optional: true,
 		},
+		// The API returns a default set of columns when none are configured
 		"columns": {
 			Type:     schema.TypeList,
 			Optional: true,
+			Computed: true,
 			Elem:     &schema.Schema{Type: schema.TypeString},
 		},
 		"title": {
@@ -2230,8 +2453,9 @@ func getLogStreamDefinitionSchema() map[string]*schema.Schema {
 			Optional: true,
 		},
 		"title_size": {
-			Type:     schema.TypeString,
-			Optional: true,
+			Type:             schema.TypeString,
+			Optional:         true,
+			DiffSuppressFunc: suppressTitleSizeDiff,
 		},
 		"title_align": {
 			Type:     schema.TypeString,
@@ -2315,24 +2539,28 @@ func buildTerraformLogStreamDefinition(datadogDefinition datadog.LogStreamDefini
 func getManageStatusDefinitionSchema() map[string]*schema.Schema {
 	return map[string]*schema.Schema{
 		"query": {
-			Type:     schema.TypeString,
-			Required: true,
+			Type:         schema.TypeString,
+			Required:     true,
+			ValidateFunc: validation.NoZeroValues,
 		},
 		"sort": {
 			Type:     schema.TypeString,
 			Optional: true,
 		},
 		"count": {
-			Type:     schema.TypeInt,
-			Optional: true,
+			Type:         schema.TypeInt,
+			Optional:     true,
+			ValidateFunc: validation.IntAtLeast(0),
 		},
 		"start": {
-			Type:     schema.TypeInt,
-			Optional: true,
+			Type:         schema.TypeInt,
+			Optional:     true,
+			ValidateFunc: validation.IntAtLeast(0),
 		},
 		"display_format": {
 			Type:     schema.TypeString,
 			Optional: true,
+			Default:  "countsAndList",
 		},
 		"color_preference": {
 			Type:     schema.TypeString,
@@ -2347,8 +2575,9 @@ func getManageStatusDefinitionSchema() map[string]*schema.Schema {
 			Optional: true,
 		},
 		"title_size": {
-			Type:     schema.TypeString,
-			Optional: true,
+			Type:             schema.TypeString,
+			Optional:         true,
+			DiffSuppressFunc: suppressTitleSizeDiff,
 		},
 		"title_align": {
 			Type:     schema.TypeString,
@@ -2366,10 +2595,10 @@ func buildDatadogManageStatusDefinition(terraformDefinition map[string]interface
 	if v, ok := terraformDefinition["sort"].(string); ok && len(v) != 0 {
 		datadogDefinition.SetSort(v)
 	}
-	if v, ok := terraformDefinition["count"].(int); ok {
+	if v, ok := terraformDefinition["count"].(int); ok && v != 0 {
 		datadogDefinition.SetCount(v)
 	}
-	if v, ok := terraformDefinition["start"].(int); ok {
+	if v, ok := terraformDefinition["start"].(int); ok && v != 0 {
 		datadogDefinition.SetStart(v)
 	}
 	if v, ok := terraformDefinition["display_format"].(string); ok && len(v) != 0 {
@@ -2447,8 +2676,9 @@ func getNoteDefinitionSchema() map[string]*schema.Schema {
 			Optional: true,
 		},
 		"text_align": {
-			Type:     schema.TypeString,
-			Optional: true,
+			Type:         schema.TypeString,
+			Optional:     true,
+			ValidateFunc: validateWidgetTextAlign,
 		},
 		"show_tick": {
 			Type:     schema.TypeBool,
@@ -2540,20 +2770,23 @@ func getQueryValueDefinitionSchema() map[string]*schema.Schema {
 			Optional: true,
 		},
 		"precision": {
-			Type:     schema.TypeInt,
-			Optional: true,
+			Type:         schema.TypeInt,
+			Optional:     true,
+			ValidateFunc: validation.IntBetween(0, 15),
 		},
 		"text_align": {
-			Type:     schema.TypeString,
-			Optional: true,
+			Type:         schema.TypeString,
+			Optional:     true,
+			ValidateFunc: validateWidgetTextAlign,
 		},
 		"title": {
 			Type:     schema.TypeString,
 			Optional: true,
 		},
 		"title_size": {
-			Type:     schema.TypeString,
-			Optional: true,
+			Type:             schema.TypeString,
+			Optional:         true,
+			DiffSuppressFunc: suppressTitleSizeDiff,
 		},
 		"title_align": {
 			Type:     schema.TypeString,
@@ -2568,12 +2801,16 @@ func getQueryValueDefinitionSchema() map[string]*schema.Schema {
 		},
 	}
 }
-func buildDatadogQueryValueDefinition(terraformDefinition map[string]interface{}) *datadog.QueryValueDefinition {
+func buildDatadogQueryValueDefinition(terraformDefinition map[string]interface{}) (*datadog.QueryValueDefinition, error) {
 	datadogDefinition := &datadog.QueryValueDefinition{}
 	// Required params
 	datadogDefinition.SetType(datadog.QUERY_VALUE_WIDGET)
 	terraformRequests := terraformDefinition["request"].([]interface{})
-	datadogDefinition.Requests = *buildDatadogQueryValueRequests(&terraformRequests)
+	datadogRequests, err := buildDatadogQueryValueRequests(&terraformRequests)
+	if err != nil {
+		return nil, err
+	}
+	datadogDefinition.Requests = *datadogRequests
 	// Optional params
 	if v, ok := terraformDefinition["autoscale"].(bool); ok {
 		datadogDefinition.SetAutoscale(v)
@@ -2599,7 +2836,7 @@ func buildDatadogQueryValueDefinition(terraformDefinition map[string]interface{}
 	if v, ok := terraformDefinition["time"].(map[string]interface{}); ok && len(v) > 0 {
 		datadogDefinition.SetTime(*buildDatadogWidgetTime(v))
 	}
-	return datadogDefinition
+	return datadogDefinition, nil
 }
 func buildTerraformQueryValueDefinition(datadogDefinition datadog.QueryValueDefinition) map[string]interface{} {
 	terraformDefinition := map[string]interface{}{}
@@ -2634,12 +2871,7 @@ func buildTerraformQueryValueDefinition(datadogDefinition datadog.QueryValueDefi
 }
 
 func getQueryValueRequestSchema() map[string]*schema.Schema {
-	return map[string]*schema.Schema{
-		// A request should implement exactly one of the following type of query
-		"q":             getMetricQuerySchema(),
-		"apm_query":     getApmOrLogQuerySchema(),
-		"log_query":     getApmOrLogQuerySchema(),
-		"process_query": getProcessQuerySchema(),
+	requestSchema := map[string]*schema.Schema{
 		// Settings specific to QueryValue requests
 		"conditional_formats": {
 			Type:     schema.TypeList,
@@ -2651,13 +2883,22 @@ func getQueryValueRequestSchema() map[string]*schema.Schema {
 		"aggregator": {
 			Type:     schema.TypeString,
 			Optional: true,
+			Default:  "avg",
 		},
 	}
+	// A request should implement exactly one of the query types
+	for key, querySchema := range getWidgetRequestQuerySchema() {
+		requestSchema[key] = querySchema
+	}
+	return requestSchema
 }
-func buildDatadogQueryValueRequests(terraformRequests *[]interface{}) *[]datadog.QueryValueRequest {
+func buildDatadogQueryValueRequests(terraformRequests *[]interface{}) (*[]datadog.QueryValueRequest, error) {
 	datadogRequests := make([]datadog.QueryValueRequest, len(*terraformRequests))
 	for i, _request := range *terraformRequests {
 		terraformRequest := _request.(map[string]interface{})
+		if err := validateWidgetRequestQuery(terraformRequest); err != nil {
+			return nil, fmt.Errorf("request.%d: %s", i, err)
+		}
 		// Build QueryValueRequest
 		datadogQueryValueRequest := datadog.QueryValueRequest{}
 		if v, ok := terraformRequest["q"].(string); ok && len(v) != 0 {
@@ -2682,7 +2923,7 @@ func buildDatadogQueryValueRequests(terraformRequests *[]interface{}) *[]datadog
 
 		datadogRequests[i] = datadogQueryValueRequest
 	}
-	return &datadogRequests
+	return &datadogRequests, nil
 }
 func buildTerraformQueryValueRequests(datadogQueryValueRequests *[]datadog.QueryValueRequest) *[]map[string]interface{} {
 	terraformRequests := make([]map[string]interface{}, len(*datadogQueryValueRequests))
@@ -2730,6 +2971,7 @@ func getScatterplotDefinitionSchema() map[string]*schema.Schema {
 					"x": {
 						Type:     schema.TypeList,
 						Optional: true,
+						MaxItems: 1,
 						Elem: &schema.Resource{
 							Schema: getScatterplotRequestSchema(),
 						},
@@ -2737,6 +2979,7 @@ func getScatterplotDefinitionSchema() map[string]*schema.Schema {
 					"y": {
 						Type:     schema.TypeList,
 						Optional: true,
+						MaxItems: 1,
 						Elem: &schema.Resource{
 							Schema: getScatterplotRequestSchema(),
 						},
@@ -2770,8 +3013,9 @@ func getScatterplotDefinitionSchema() map[string]*schema.Schema {
 			Optional: true,
 		},
 		"title_size": {
-			Type:     schema.TypeString,
-			Optional: true,
+			Type:             schema.TypeString,
+			Optional:         true,
+			DiffSuppressFunc: suppressTitleSizeDiff,
 		},
 		"title_align": {
 			Type:     schema.TypeString,
@@ -2861,7 +3105,8 @@ func buildTerraformScatterplotDefinition(datadogDefinition datadog.ScatterplotDe
 		terraformDefinition["yaxis"] = []map[string]interface{}{_axis}
 	}
 
-	if datadogDefinition.ColorByGroups != nil {
+	// The API may return an empty list when color_by_groups is unset
+	if len(datadogDefinition.ColorByGroups) > 0 {
 		terraformColorByGroups := make([]string, len(datadogDefinition.ColorByGroups))
 		for i, datadogColorByGroup := range datadogDefinition.ColorByGroups {
 			terraformColorByGroups[i] = datadogColorByGroup
@@ -2884,18 +3129,19 @@ func buildTerraformScatterplotDefinition(datadogDefinition datadog.ScatterplotDe
 }
 
 func getScatterplotRequestSchema() map[string]*schema.Schema {
-	return map[string]*schema.Schema{
-		// A request should implement exactly one of the following type of query
-		"q":             getMetricQuerySchema(),
-		"apm_query":     getApmOrLogQuerySchema(),
-		"log_query":     getApmOrLogQuerySchema(),
-		"process_query": getProcessQuerySchema(),
+	requestSchema := map[string]*schema.Schema{
 		// Settings specific to Scatterplot requests
 		"aggregator": {
 			Type:     schema.TypeString,
 			Optional: true,
+			Default:  "avg",
 		},
 	}
+	// A request should implement exactly one of the query types
+	for key, querySchema := range getWidgetRequestQuerySchema() {
+		requestSchema[key] = querySchema
+	}
+	return requestSchema
 }
 func buildDatadogScatterplotRequest(terraformRequest map[string]interface{}) *datadog.ScatterplotRequest {
 
@@ -2980,8 +3226,9 @@ func getTimeseriesDefinitionSchema() map[string]*schema.Schema {
 			Optional: true,
 		},
 		"title_size": {
-			Type:     schema.TypeString,
-			Optional: true,
+			Type:             schema.TypeString,
+			Optional:         true,
+			DiffSuppressFunc: suppressTitleSizeDiff,
 		},
 		"title_align": {
 			Type:     schema.TypeString,
@@ -3005,12 +3252,16 @@ func getTimeseriesDefinitionSchema() map[string]*schema.Schema {
 	}
 }
 
-func buildDatadogTimeseriesDefinition(terraformDefinition map[string]interface{}) *datadog.TimeseriesDefinition {
+func buildDatadogTimeseriesDefinition(terraformDefinition map[string]interface{}) (*datadog.TimeseriesDefinition, error) {
 	datadogDefinition := &datadog.TimeseriesDefinition{}
 	// Required params
 	datadogDefinition.Type = datadog.String(datadog.TIMESERIES_WIDGET)
 	terraformRequests := terraformDefinition["request"].([]interface{})
-	datadogDefinition.Requests = *buildDatadogTimeseriesRequests(&terraformRequests)
+	datadogRequests, err := buildDatadogTimeseriesRequests(&terraformRequests)
+	if err != nil {
+		return nil, err
+	}
+	datadogDefinition.Requests = *datadogRequests
 	// Optional params
 	if v, ok := terraformDefinition["marker"].([]interface{}); ok && len(v) > 0 {
 		datadogDefinition.Markers = *buildDatadogWidgetMarkers(&v)
@@ -3038,7 +3289,7 @@ func buildDatadogTimeseriesDefinition(terraformDefinition map[string]interface{}
 	if v, ok := terraformDefinition["show_legend"].(bool); ok {
 		datadogDefinition.ShowLegend = datadog.Bool(v)
 	}
-	return datadogDefinition
+	return datadogDefinition, nil
 }
 
 func buildTerraformTimeseriesDefinition(datadogDefinition datadog.TimeseriesDefinition) map[string]interface{} {
@@ -3075,12 +3326,7 @@ func buildTerraformTimeseriesDefinition(datadogDefinition datadog.TimeseriesDefi
 }
 
 func getTimeseriesRequestSchema() map[string]*schema.Schema {
-	return map[string]*schema.Schema{
-		// A request should implement exactly one of the following type of query
-		"q":             getMetricQuerySchema(),
-		"apm_query":     getApmOrLogQuerySchema(),
-		"log_query":     getApmOrLogQuerySchema(),
-		"process_query": getProcessQuerySchema(),
+	requestSchema := map[string]*schema.Schema{
 		// Settings specific to Timeseries requests
 		"style": {
 			Type:     schema.TypeList,
@@ -3124,11 +3370,19 @@ func getTimeseriesRequestSchema() map[string]*schema.Schema {
 			Optional: true,
 		},
 	}
+	// A request should implement exactly one of the query types
+	for key, querySchema := range getWidgetRequestQuerySchema() {
+		requestSchema[key] = querySchema
+	}
+	return requestSchema
 }
-func buildDatadogTimeseriesRequests(terraformRequests *[]interface{}) *[]datadog.TimeseriesRequest {
+func buildDatadogTimeseriesRequests(terraformRequests *[]interface{}) (*[]datadog.TimeseriesRequest, error) {
 	datadogRequests := make([]datadog.TimeseriesRequest, len(*terraformRequests))
 	for i, _request := range *terraformRequests {
 		terraformRequest := _request.(map[string]interface{})
+		if err := validateWidgetRequestQuery(terraformRequest); err != nil {
+			return nil, fmt.Errorf("request.%d: %s", i, err)
+		}
 		// Build TimeseriesRequest
 		datadogTimeseriesRequest := datadog.TimeseriesRequest{}
 		if v, ok := terraformRequest["q"].(string); ok && len(v) != 0 {
@@ -3170,7 +3424,7 @@ func buildDatadogTimeseriesRequests(terraformRequests *[]interface{}) *[]datadog
 		}
 		datadogRequests[i] = datadogTimeseriesRequest
 	}
-	return &datadogRequests
+	return &datadogRequests, nil
 }
 func buildTerraformTimeseriesRequests(datadogTimeseriesRequests *[]datadog.TimeseriesRequest) *[]map[string]interface{} {
 	terraformRequests := make([]map[string]interface{}, len(*datadogTimeseriesRequests))
@@ -3192,8 +3446,8 @@ func buildTerraformTimeseriesRequests(datadogTimeseriesRequests *[]datadog.Times
 			_style := buildTerraformTimeseriesRequestStyle(*datadogRequest.Style)
 			terraformRequest["style"] = []map[string]interface{}{_style}
 		}
-		// Metadata
-		if datadogRequest.Metadata != nil {
+		// Metadata, the API may return an empty list when no aliases are set
+		if len(datadogRequest.Metadata) > 0 {
 			terraformMetadataList := make([]map[string]interface{}, len(datadogRequest.Metadata))
 			for i, metadata := range datadogRequest.Metadata {
 				// Expression
@@ -3235,8 +3489,9 @@ func getToplistDefinitionSchema() map[string]*schema.Schema {
 			Optional: true,
 		},
 		"title_size": {
-			Type:     schema.TypeString,
-			Optional: true,
+			Type:             schema.TypeString,
+			Optional:         true,
+			DiffSuppressFunc: suppressTitleSizeDiff,
 		},
 		"title_align": {
 			Type:     schema.TypeString,
@@ -3251,12 +3506,16 @@ func getToplistDefinitionSchema() map[string]*schema.Schema {
 		},
 	}
 }
-func buildDatadogToplistDefinition(terraformDefinition map[string]interface{}) *datadog.ToplistDefinition {
+func buildDatadogToplistDefinition(terraformDefinition map[string]interface{}) (*datadog.ToplistDefinition, error) {
 	datadogDefinition := &datadog.ToplistDefinition{}
 	// Required params
 	datadogDefinition.SetType(datadog.TOPLIST_WIDGET)
 	terraformRequests := terraformDefinition["request"].([]interface{})
-	datadogDefinition.Requests = *buildDatadogToplistRequests(&terraformRequests)
+	datadogRequests, err := buildDatadogToplistRequests(&terraformRequests)
+	if err != nil {
+		return nil, err
+	}
+	datadogDefinition.Requests = *datadogRequests
 	// Optional params
 	if v, ok := terraformDefinition["title"].(string); ok && len(v) != 0 {
 		datadogDefinition.Title = datadog.String(v)
@@ -3270,7 +3529,7 @@ func buildDatadogToplistDefinition(terraformDefinition map[string]interface{}) *
 	if v, ok := terraformDefinition["time"].(map[string]interface{}); ok && len(v) > 0 {
 		datadogDefinition.Time = buildDatadogWidgetTime(v)
 	}
-	return datadogDefinition
+	return datadogDefinition, nil
 }
 func buildTerraformToplistDefinition(datadogDefinition datadog.ToplistDefinition) map[string]interface{} {
 	terraformDefinition := map[string]interface{}{}
@@ -3293,12 +3552,7 @@ func buildTerraformToplistDefinition(datadogDefinition datadog.ToplistDefinition
 }
 
 func getToplistRequestSchema() map[string]*schema.Schema {
-	return map[string]*schema.Schema{
-		// A request should implement exactly one of the following type of query
-		"q":             getMetricQuerySchema(),
-		"apm_query":     getApmOrLogQuerySchema(),
-		"log_query":     getApmOrLogQuerySchema(),
-		"process_query": getProcessQuerySchema(),
+	requestSchema := map[string]*schema.Schema{
 		// Settings specific to Toplist requests
 		"conditional_formats": {
 			Type:     schema.TypeList,
@@ -3316,11 +3570,19 @@ func getToplistRequestSchema() map[string]*schema.Schema {
 			},
 		},
 	}
+	// A request should implement exactly one of the query types
+	for key, querySchema := range getWidgetRequestQuerySchema() {
+		requestSchema[key] = querySchema
+	}
+	return requestSchema
 }
-func buildDatadogToplistRequests(terraformRequests *[]interface{}) *[]datadog.ToplistRequest {
+func buildDatadogToplistRequests(terraformRequests *[]interface{}) (*[]datadog.ToplistRequest, error) {
 	datadogRequests := make([]datadog.ToplistRequest, len(*terraformRequests))
 	for i, _request := range *terraformRequests {
 		terraformRequest := _request.(map[string]interface{})
+		if err := validateWidgetRequestQuery(terraformRequest); err != nil {
+			return nil, fmt.Errorf("request.%d: %s", i, err)
+		}
 		// Build ToplistRequest
 		datadogToplistRequest := datadog.ToplistRequest{}
 		if v, ok := terraformRequest["q"].(string); ok && len(v) != 0 {
@@ -3345,7 +3607,7 @@ func buildDatadogToplistRequests(terraformRequests *[]interface{}) *[]datadog.To
 		}
 		datadogRequests[i] = datadogToplistRequest
 	}
-	return &datadogRequests
+	return &datadogRequests, nil
 }
 func buildTerraformToplistRequests(datadogToplistRequests *[]datadog.ToplistRequest) *[]map[string]interface{} {
 	terraformRequests := make([]map[string]interface{}, len(*datadogToplistRequests))
@@ -3432,8 +3694,9 @@ func getTraceServiceDefinitionSchema() map[string]*schema.Schema {
 			Optional: true,
 		},
 		"title_size": {
-			Type:     schema.TypeString,
-			Optional: true,
+			Type:             schema.TypeString,
+			Optional:         true,
+			DiffSuppressFunc: suppressTitleSizeDiff,
 		},
 		"title_align": {
 			Type:     schema.TypeString,
@@ -3566,8 +3829,9 @@ func getWidgetConditionalFormatSchema() map[string]*schema.Schema {
 			Optional: true,
 		},
 		"image_url": {
-			Type:     schema.TypeString,
-			Optional: true,
+			Type:         schema.TypeString,
+			Optional:     true,
+			ValidateFunc: validateConditionalFormatImageUrl,
 		},
 		"hide_value": {
 			Type:     schema.TypeBool,
@@ -3577,6 +3841,10 @@ func getWidgetConditionalFormatSchema() map[string]*schema.Schema {
 			Type:     schema.TypeString,
 			Optional: true,
 		},
+		"metric": {
+			Type:     schema.TypeString,
+			Optional: true,
+		},
 	}
 }
 func buildDatadogWidgetConditionalFormat(terraformWidgetConditionalFormat *[]interface{}) *[]datadog.WidgetConditionalFormat {
@@ -3604,10 +3872,16 @@ func buildDatadogWidgetConditionalFormat(terraformWidgetConditionalFormat *[]int
 		if v, ok := terraformConditionalFormat["timeframe"].(string); ok && len(v) != 0 {
 			datadogConditionalFormat.SetTimeframe(v)
 		}
+		if v, ok := terraformConditionalFormat["metric"].(string); ok && len(v) != 0 {
+			datadogConditionalFormat.SetMetric(v)
+		}
 		datadogWidgetConditionalFormat[i] = datadogConditionalFormat
 	}
 	return &datadogWidgetConditionalFormat
 }
+
+// Conditional formats are evaluated in order, so they are read back index by index
+// to keep the configured order and avoid spurious diffs.
 func buildTerraformWidgetConditionalFormat(datadogWidgetConditionalFormat *[]datadog.WidgetConditionalFormat) *[]map[string]interface{} {
 	terraformWidgetConditionalFormat := make([]map[string]interface{}, len(*datadogWidgetConditionalFormat))
 	for i, datadogConditionalFormat := range *datadogWidgetConditionalFormat {
@@ -3632,6 +3906,9 @@ func buildTerraformWidgetConditionalFormat(datadogWidgetConditionalFormat *[]dat
 		if datadogConditionalFormat.Timeframe != nil {
 			terraformConditionalFormat["timeframe"] = *datadogConditionalFormat.Timeframe
 		}
+		if datadogConditionalFormat.Metric != nil {
+			terraformConditionalFormat["metric"] = *datadogConditionalFormat.Metric
+		}
 		terraformWidgetConditionalFormat[i] = terraformConditionalFormat
 	}
 	return &terraformWidgetConditionalFormat
@@ -3753,14 +4030,88 @@ func buildTerraformWidgetMarkers(datadogWidgetMarkers *[]datadog.WidgetMarker) *
 // Widget Query helpers
 //
 
+// The query types of a widget request, a request should implement exactly one of them
+func getWidgetRequestQuerySchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"q":             getMetricQuerySchema(),
+		"apm_query":     getApmOrLogQuerySchema(),
+		"log_query":     getApmOrLogQuerySchema(),
+		"process_query": getProcessQuerySchema(),
+	}
+}
+
+// Helper to list the query types of a widget request in a stable order
+func getWidgetRequestQueryTypes() []string {
+	var queryTypes []string
+	for key := range getWidgetRequestQuerySchema() {
+		queryTypes = append(queryTypes, key)
+	}
+	sort.Strings(queryTypes)
+	return queryTypes
+}
+
+// Helper to check that a widget request sets exactly one query type
+func validateWidgetRequestQuery(terraformRequest map[string]interface{}) error {
+	queryKeys := getWidgetRequestQueryKeys(terraformRequest)
+	switch len(queryKeys) {
+	case 0:
+		return fmt.Errorf("exactly one of %s is required, got none",
+			strings.Join(getWidgetRequestQueryTypes(), ", "))
+	case 1:
+		return nil
+	default:
+		return fmt.Errorf("exactly one of %s is required, got %s",
+			strings.Join(getWidgetRequestQueryTypes(), ", "), strings.Join(queryKeys, ", "))
+	}
+}
+
+// Helper to list the query types actually set on a widget request
+func getWidgetRequestQueryKeys(terraformRequest map[string]interface{}) []string {
+	var queryKeys []string
+	querySchema := getWidgetRequestQuerySchema()
+	for _, key := range getWidgetRequestQueryTypes() {
+		switch querySchema[key].Type {
+		case schema.TypeString:
+			if v, ok := terraformRequest[key].(string); ok && len(v) != 0 {
+				queryKeys = append(queryKeys, key)
+			}
+		case schema.TypeList:
+			if v, ok := terraformRequest[key].([]interface{}); ok && len(v) > 0 {
+				queryKeys = append(queryKeys, key)
+			}
+		}
+	}
+	return queryKeys
+}
+
 // Metric Query
 func getMetricQuerySchema() *schema.Schema {
 	return &schema.Schema{
-		Type:     schema.TypeString,
-		Optional: true,
+		Type:             schema.TypeString,
+		Optional:         true,
+		DiffSuppressFunc: suppressMetricQueryWhitespaceDiff,
 	}
 }
 
+// The API trims surrounding whitespace from metric queries, so long queries
+// written as multi-line strings shouldn't produce a diff on the next plan
+func suppressMetricQueryWhitespaceDiff(k, old, new string, d *schema.ResourceData) bool {
+	return strings.TrimSpace(old) == strings.TrimSpace(new)
+}
+
+// The API returns a numeric title_size such as "16.0" as "16", so numerically equal sizes don't diff
+func suppressTitleSizeDiff(k, old, new string, d *schema.ResourceData) bool {
+	oF, err := strconv.ParseFloat(old, 64)
+	if err != nil {
+		return false
+	}
+	nF, err := strconv.ParseFloat(new, 64)
+	if err != nil {
+		return false
+	}
+	return oF == nF
+}
+
 // APM or Log Query
 func getApmOrLogQuerySchema() *schema.Schema {
 	return &schema.Schema{
@@ -4040,8 +4391,9 @@ func getWidgetAxisSchema() map[string]*schema.Schema {
 			Optional: true,
 		},
 		"scale": {
-			Type:     schema.TypeString,
-			Optional: true,
+			Type:         schema.TypeString,
+			Optional:     true,
+			ValidateFunc: validateWidgetAxisScale,
 		},
 		"min": {
 			Type:     schema.TypeString,
@@ -4211,3 +4563,103 @@ func validateGroupWidgetLayoutType(val interface{}, key string) (warns []string,
 	}
 	return
 }
+
+var widgetHexColorRegexp = regexp.MustCompile(`^#([0-9a-fA-F]{3}|[0-9a-fA-F]{6})$`)
+
+func validateWidgetColor(val interface{}, key string) (warns []string, errs []error) {
+	value := val.(string)
+	if widgetHexColorRegexp.MatchString(value) {
+		return
+	}
+	switch value {
+	case "white", "gray", "yellow", "blue", "purple", "pink", "orange", "red", "green":
+		break
+	default:
+		errs = append(errs, fmt.Errorf(
+			"%q contains an invalid value %q. Valid values are a hex color (e.g. `#d00`) or one of `white`, `gray`, `yellow`, `blue`, `purple`, `pink`, `orange`, `red`, `green`", key, value))
+	}
+	return
+}
+
+func validateEventStreamEventSize(val interface{}, key string) (warns []string, errs []error) {
+	value := val.(string)
+	switch value {
+	case "s", "l":
+		break
+	default:
+		errs = append(errs, fmt.Errorf(
+			"%q contains an invalid value %q. Valid values are `s` or `l`", key, value))
+	}
+	return
+}
+
+func validateWidgetTextAlign(val interface{}, key string) (warns []string, errs []error) {
+	value := val.(string)
+	switch value {
+	case "center", "left", "right":
+		break
+	default:
+		errs = append(errs, fmt.Errorf(
+			"%q contains an invalid value %q. Valid values are `center`, `left` or `right`", key, value))
+	}
+	return
+}
+
+// image_url can reference template values, so only reject values that are
+// neither an http(s) URL nor a relative path
+func validateConditionalFormatImageUrl(val interface{}, key string) (warns []string, errs []error) {
+	value := val.(string)
+	u, err := url.Parse(value)
+	if err != nil || strings.ContainsAny(value, " \t\n") ||
+		(u.IsAbs() && ((u.Scheme != "http" && u.Scheme != "https") || len(u.Host) == 0)) {
+		errs = append(errs, fmt.Errorf(
+			"%q contains an invalid value %q. It should be an http(s) URL or a relative path", key, value))
+	}
+	return
+}
+
+func validateAbsoluteUrl(val interface{}, key string) (warns []string, errs []error) {
+	value := val.(string)
+	u, err := url.Parse(value)
+	if err != nil || !u.IsAbs() || len(u.Host) == 0 {
+		errs = append(errs, fmt.Errorf(
+			"%q contains an invalid value %q. It should be an absolute URL, e.g. `https://example.com`", key, value))
+	}
+	return
+}
+
+func validateHostmapNodeType(val interface{}, key string) (warns []string, errs []error) {
+	value := val.(string)
+	switch value {
+	case "host", "container":
+		break
+	default:
+		errs = append(errs, fmt.Errorf(
+			"%q contains an invalid value %q. Valid values are `host` or `container`", key, value))
+	}
+	return
+}
+
+func validateWidgetAxisScale(val interface{}, key string) (warns []string, errs []error) {
+	value := val.(string)
+	switch value {
+	case "linear", "log", "pow", "sqrt":
+		break
+	default:
+		errs = append(errs, fmt.Errorf(
+			"%q contains an invalid value %q. Valid values are `linear`, `log`, `pow` or `sqrt`", key, value))
+	}
+	return
+}
+
+func validateChangeType(val interface{}, key string) (warns []string, errs []error) {
+	value := val.(string)
+	switch value {
+	case "absolute", "relative":
+		break
+	default:
+		errs = append(errs, fmt.Errorf(
+			"%q contains an invalid value %q. Valid values are `absolute` or `relative`", key, value))
+	}
+	return
+}
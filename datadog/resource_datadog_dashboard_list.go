@@ -150,6 +150,11 @@ func resourceDatadogDashboardListExists(d *schema.ResourceData, meta interface{}
 }
 
 func resourceDatadogDashboardListImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	// Dashboard lists are identified by their numeric ID, the dash_item
+	// membership is then reconstructed from the API by the Read function
+	if _, err := strconv.Atoi(d.Id()); err != nil {
+		return nil, fmt.Errorf("Dashboard list ID must be numeric, got %q", d.Id())
+	}
 	if err := resourceDatadogDashboardListRead(d, meta); err != nil {
 		return nil, err
 	}
@@ -95,6 +95,19 @@ func TestDatadogDashListImport(t *testing.T) {
 	})
 }
 
+func TestDatadogDashListImport_invalidID(t *testing.T) {
+	d := resourceDatadogDashboardList().Data(nil)
+	d.SetId("abc-def-ghi")
+
+	_, err := resourceDatadogDashboardListImport(d, nil)
+	if err == nil {
+		t.Fatal("expected an error when importing a non-numeric ID")
+	}
+	if !strings.Contains(err.Error(), "must be numeric") {
+		t.Errorf("unexpected error: %s", err)
+	}
+}
+
 func testAccCheckDatadogDashListDestroy(s *terraform.State) error {
 	client := testAccProvider.Meta().(*datadog.Client)
 
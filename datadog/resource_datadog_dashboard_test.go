@@ -1,11 +1,14 @@
 package datadog
 
 import (
+	"encoding/json"
 	"fmt"
 	"strings"
 	"testing"
 
+	tfconfig "github.com/hashicorp/terraform/config"
 	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/hashicorp/terraform/terraform"
 	datadog "github.com/zorkian/go-datadog-api"
 )
@@ -817,6 +820,334 @@ func TestAccDatadogDashboard_update(t *testing.T) {
 	})
 }
 
+const datadogDashboardNestedGroupConfig = `
+resource "datadog_dashboard" "nested_group_dashboard" {
+	title         = "Acceptance Test Nested Group Dashboard"
+	description   = "Created using the Datadog provider in Terraform"
+	layout_type   = "ordered"
+	is_read_only  = false
+	widget {
+		group_definition {
+			layout_type = "ordered"
+			title = "Mixed Group Widget"
+
+			widget {
+				timeseries_definition {
+					request {
+						q = "avg:system.cpu.user{app:general} by {env}"
+						display_type = "line"
+					}
+					request {
+						q = "avg:system.cpu.system{app:general} by {env}"
+						display_type = "area"
+					}
+					title = "Nested Timeseries"
+				}
+			}
+			widget {
+				query_value_definition {
+					request {
+						q = "avg:system.load.1{env:staging} by {account}"
+						aggregator = "sum"
+						conditional_formats {
+							comparator = "<"
+							value = "2"
+							palette = "white_on_green"
+						}
+						conditional_formats {
+							comparator = ">"
+							value = "2.2"
+							palette = "white_on_red"
+						}
+					}
+					title = "Nested Query Value"
+				}
+			}
+			widget {
+				note_definition {
+					content = "nested note widget"
+					background_color = "pink"
+					font_size = "14"
+					text_align = "center"
+				}
+			}
+		}
+	}
+}
+`
+
+func TestAccDatadogDashboard_nestedGroup(t *testing.T) {
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: checkDashboardDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: datadogDashboardNestedGroupConfig,
+				Check: resource.ComposeTestCheckFunc(
+					checkDashboardExists,
+					resource.TestCheckResourceAttr("datadog_dashboard.nested_group_dashboard", "widget.#", "1"),
+					// Group widget
+					resource.TestCheckResourceAttr("datadog_dashboard.nested_group_dashboard", "widget.0.group_definition.0.layout_type", "ordered"),
+					resource.TestCheckResourceAttr("datadog_dashboard.nested_group_dashboard", "widget.0.group_definition.0.title", "Mixed Group Widget"),
+					resource.TestCheckResourceAttr("datadog_dashboard.nested_group_dashboard", "widget.0.group_definition.0.widget.#", "3"),
+					// Nested Timeseries widget
+					resource.TestCheckResourceAttr("datadog_dashboard.nested_group_dashboard", "widget.0.group_definition.0.widget.0.timeseries_definition.0.request.#", "2"),
+					resource.TestCheckResourceAttr("datadog_dashboard.nested_group_dashboard", "widget.0.group_definition.0.widget.0.timeseries_definition.0.request.0.q", "avg:system.cpu.user{app:general} by {env}"),
+					resource.TestCheckResourceAttr("datadog_dashboard.nested_group_dashboard", "widget.0.group_definition.0.widget.0.timeseries_definition.0.request.0.display_type", "line"),
+					resource.TestCheckResourceAttr("datadog_dashboard.nested_group_dashboard", "widget.0.group_definition.0.widget.0.timeseries_definition.0.request.1.q", "avg:system.cpu.system{app:general} by {env}"),
+					resource.TestCheckResourceAttr("datadog_dashboard.nested_group_dashboard", "widget.0.group_definition.0.widget.0.timeseries_definition.0.request.1.display_type", "area"),
+					resource.TestCheckResourceAttr("datadog_dashboard.nested_group_dashboard", "widget.0.group_definition.0.widget.0.timeseries_definition.0.title", "Nested Timeseries"),
+					// Nested Query Value widget
+					resource.TestCheckResourceAttr("datadog_dashboard.nested_group_dashboard", "widget.0.group_definition.0.widget.1.query_value_definition.0.request.0.q", "avg:system.load.1{env:staging} by {account}"),
+					resource.TestCheckResourceAttr("datadog_dashboard.nested_group_dashboard", "widget.0.group_definition.0.widget.1.query_value_definition.0.request.0.aggregator", "sum"),
+					resource.TestCheckResourceAttr("datadog_dashboard.nested_group_dashboard", "widget.0.group_definition.0.widget.1.query_value_definition.0.request.0.conditional_formats.#", "2"),
+					resource.TestCheckResourceAttr("datadog_dashboard.nested_group_dashboard", "widget.0.group_definition.0.widget.1.query_value_definition.0.request.0.conditional_formats.0.comparator", "<"),
+					resource.TestCheckResourceAttr("datadog_dashboard.nested_group_dashboard", "widget.0.group_definition.0.widget.1.query_value_definition.0.request.0.conditional_formats.0.value", "2"),
+					resource.TestCheckResourceAttr("datadog_dashboard.nested_group_dashboard", "widget.0.group_definition.0.widget.1.query_value_definition.0.request.0.conditional_formats.0.palette", "white_on_green"),
+					resource.TestCheckResourceAttr("datadog_dashboard.nested_group_dashboard", "widget.0.group_definition.0.widget.1.query_value_definition.0.request.0.conditional_formats.1.comparator", ">"),
+					resource.TestCheckResourceAttr("datadog_dashboard.nested_group_dashboard", "widget.0.group_definition.0.widget.1.query_value_definition.0.request.0.conditional_formats.1.value", "2.2"),
+					resource.TestCheckResourceAttr("datadog_dashboard.nested_group_dashboard", "widget.0.group_definition.0.widget.1.query_value_definition.0.request.0.conditional_formats.1.palette", "white_on_red"),
+					resource.TestCheckResourceAttr("datadog_dashboard.nested_group_dashboard", "widget.0.group_definition.0.widget.1.query_value_definition.0.title", "Nested Query Value"),
+					// Nested Note widget
+					resource.TestCheckResourceAttr("datadog_dashboard.nested_group_dashboard", "widget.0.group_definition.0.widget.2.note_definition.0.content", "nested note widget"),
+					resource.TestCheckResourceAttr("datadog_dashboard.nested_group_dashboard", "widget.0.group_definition.0.widget.2.note_definition.0.background_color", "pink"),
+					resource.TestCheckResourceAttr("datadog_dashboard.nested_group_dashboard", "widget.0.group_definition.0.widget.2.note_definition.0.font_size", "14"),
+					resource.TestCheckResourceAttr("datadog_dashboard.nested_group_dashboard", "widget.0.group_definition.0.widget.2.note_definition.0.text_align", "center"),
+				),
+			},
+		},
+	})
+}
+
+const datadogDashboardFreeGroupConfig = `
+resource "datadog_dashboard" "free_group_dashboard" {
+	title         = "Acceptance Test Free Group Dashboard"
+	description   = "Created using the Datadog provider in Terraform"
+	layout_type   = "free"
+	is_read_only  = false
+	widget {
+		group_definition {
+			layout_type = "ordered"
+			title = "Positioned Group Widget"
+
+			widget {
+				note_definition {
+					content = "note inside a positioned group"
+				}
+			}
+		}
+		layout = {
+			height = 43
+			width = 32
+			x = 5
+			y = 5
+		}
+	}
+}
+`
+
+func TestAccDatadogDashboard_freeGroup(t *testing.T) {
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: checkDashboardDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: datadogDashboardFreeGroupConfig,
+				Check: resource.ComposeTestCheckFunc(
+					checkDashboardExists,
+					resource.TestCheckResourceAttr("datadog_dashboard.free_group_dashboard", "layout_type", "free"),
+					resource.TestCheckResourceAttr("datadog_dashboard.free_group_dashboard", "widget.#", "1"),
+					// Group widget
+					resource.TestCheckResourceAttr("datadog_dashboard.free_group_dashboard", "widget.0.group_definition.0.layout_type", "ordered"),
+					resource.TestCheckResourceAttr("datadog_dashboard.free_group_dashboard", "widget.0.group_definition.0.title", "Positioned Group Widget"),
+					resource.TestCheckResourceAttr("datadog_dashboard.free_group_dashboard", "widget.0.group_definition.0.widget.0.note_definition.0.content", "note inside a positioned group"),
+					resource.TestCheckResourceAttr("datadog_dashboard.free_group_dashboard", "widget.0.layout.height", "43"),
+					resource.TestCheckResourceAttr("datadog_dashboard.free_group_dashboard", "widget.0.layout.width", "32"),
+					resource.TestCheckResourceAttr("datadog_dashboard.free_group_dashboard", "widget.0.layout.x", "5"),
+					resource.TestCheckResourceAttr("datadog_dashboard.free_group_dashboard", "widget.0.layout.y", "5"),
+				),
+			},
+		},
+	})
+}
+
+const datadogDashboardConditionalFormatsConfig = `
+resource "datadog_dashboard" "conditional_formats_dashboard" {
+	title         = "Acceptance Test Conditional Formats Dashboard"
+	description   = "Created using the Datadog provider in Terraform"
+	layout_type   = "ordered"
+	is_read_only  = false
+	widget {
+		query_value_definition {
+			request {
+				q = "avg:system.load.1{env:staging} by {account}"
+				aggregator = "sum"
+				conditional_formats {
+					comparator = "<"
+					value = "2"
+					palette = "custom_bg"
+					custom_bg_color = "#d00"
+					hide_value = false
+				}
+				conditional_formats {
+					comparator = ">"
+					value = "2.2"
+					palette = "custom_image"
+					image_url = "https://example.com/images/alert.png"
+					hide_value = true
+				}
+			}
+			title = "Conditional Formats"
+		}
+	}
+}
+`
+
+func TestAccDatadogDashboard_conditionalFormats(t *testing.T) {
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: checkDashboardDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: datadogDashboardConditionalFormatsConfig,
+				Check: resource.ComposeTestCheckFunc(
+					checkDashboardExists,
+					resource.TestCheckResourceAttr("datadog_dashboard.conditional_formats_dashboard", "widget.0.query_value_definition.0.request.0.q", "avg:system.load.1{env:staging} by {account}"),
+					resource.TestCheckResourceAttr("datadog_dashboard.conditional_formats_dashboard", "widget.0.query_value_definition.0.request.0.aggregator", "sum"),
+					resource.TestCheckResourceAttr("datadog_dashboard.conditional_formats_dashboard", "widget.0.query_value_definition.0.request.0.conditional_formats.#", "2"),
+					resource.TestCheckResourceAttr("datadog_dashboard.conditional_formats_dashboard", "widget.0.query_value_definition.0.request.0.conditional_formats.0.comparator", "<"),
+					resource.TestCheckResourceAttr("datadog_dashboard.conditional_formats_dashboard", "widget.0.query_value_definition.0.request.0.conditional_formats.0.value", "2"),
+					resource.TestCheckResourceAttr("datadog_dashboard.conditional_formats_dashboard", "widget.0.query_value_definition.0.request.0.conditional_formats.0.palette", "custom_bg"),
+					resource.TestCheckResourceAttr("datadog_dashboard.conditional_formats_dashboard", "widget.0.query_value_definition.0.request.0.conditional_formats.0.custom_bg_color", "#d00"),
+					resource.TestCheckResourceAttr("datadog_dashboard.conditional_formats_dashboard", "widget.0.query_value_definition.0.request.0.conditional_formats.0.hide_value", "false"),
+					resource.TestCheckResourceAttr("datadog_dashboard.conditional_formats_dashboard", "widget.0.query_value_definition.0.request.0.conditional_formats.1.comparator", ">"),
+					resource.TestCheckResourceAttr("datadog_dashboard.conditional_formats_dashboard", "widget.0.query_value_definition.0.request.0.conditional_formats.1.value", "2.2"),
+					resource.TestCheckResourceAttr("datadog_dashboard.conditional_formats_dashboard", "widget.0.query_value_definition.0.request.0.conditional_formats.1.palette", "custom_image"),
+					resource.TestCheckResourceAttr("datadog_dashboard.conditional_formats_dashboard", "widget.0.query_value_definition.0.request.0.conditional_formats.1.image_url", "https://example.com/images/alert.png"),
+					resource.TestCheckResourceAttr("datadog_dashboard.conditional_formats_dashboard", "widget.0.query_value_definition.0.request.0.conditional_formats.1.hide_value", "true"),
+					resource.TestCheckResourceAttr("datadog_dashboard.conditional_formats_dashboard", "widget.0.query_value_definition.0.title", "Conditional Formats"),
+				),
+			},
+		},
+	})
+}
+
+const datadogDashboardToplistLogQueryConfig = `
+resource "datadog_dashboard" "toplist_log_query_dashboard" {
+	title         = "Acceptance Test Toplist Log Query Dashboard"
+	description   = "Created using the Datadog provider in Terraform"
+	layout_type   = "ordered"
+	is_read_only  = false
+	widget {
+		toplist_definition {
+			request {
+				log_query {
+					index = "mcnulty"
+					compute = {
+						aggregation = "count"
+						facet = "@duration"
+						interval = 5000
+					}
+					search = {
+						query = "status:info"
+					}
+					group_by {
+						facet = "host"
+						limit = 10
+						sort = {
+							aggregation = "avg"
+							order = "desc"
+							facet = "@duration"
+						}
+					}
+				}
+			}
+			title = "Log Query Toplist"
+		}
+	}
+}
+`
+
+func TestAccDatadogDashboard_toplistLogQuery(t *testing.T) {
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: checkDashboardDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: datadogDashboardToplistLogQueryConfig,
+				Check: resource.ComposeTestCheckFunc(
+					checkDashboardExists,
+					resource.TestCheckResourceAttr("datadog_dashboard.toplist_log_query_dashboard", "widget.0.toplist_definition.0.request.0.log_query.0.index", "mcnulty"),
+					resource.TestCheckResourceAttr("datadog_dashboard.toplist_log_query_dashboard", "widget.0.toplist_definition.0.request.0.log_query.0.compute.aggregation", "count"),
+					resource.TestCheckResourceAttr("datadog_dashboard.toplist_log_query_dashboard", "widget.0.toplist_definition.0.request.0.log_query.0.compute.facet", "@duration"),
+					resource.TestCheckResourceAttr("datadog_dashboard.toplist_log_query_dashboard", "widget.0.toplist_definition.0.request.0.log_query.0.compute.interval", "5000"),
+					resource.TestCheckResourceAttr("datadog_dashboard.toplist_log_query_dashboard", "widget.0.toplist_definition.0.request.0.log_query.0.search.query", "status:info"),
+					resource.TestCheckResourceAttr("datadog_dashboard.toplist_log_query_dashboard", "widget.0.toplist_definition.0.request.0.log_query.0.group_by.#", "1"),
+					resource.TestCheckResourceAttr("datadog_dashboard.toplist_log_query_dashboard", "widget.0.toplist_definition.0.request.0.log_query.0.group_by.0.facet", "host"),
+					resource.TestCheckResourceAttr("datadog_dashboard.toplist_log_query_dashboard", "widget.0.toplist_definition.0.request.0.log_query.0.group_by.0.limit", "10"),
+					resource.TestCheckResourceAttr("datadog_dashboard.toplist_log_query_dashboard", "widget.0.toplist_definition.0.request.0.log_query.0.group_by.0.sort.aggregation", "avg"),
+					resource.TestCheckResourceAttr("datadog_dashboard.toplist_log_query_dashboard", "widget.0.toplist_definition.0.request.0.log_query.0.group_by.0.sort.order", "desc"),
+					resource.TestCheckResourceAttr("datadog_dashboard.toplist_log_query_dashboard", "widget.0.toplist_definition.0.request.0.log_query.0.group_by.0.sort.facet", "@duration"),
+					resource.TestCheckResourceAttr("datadog_dashboard.toplist_log_query_dashboard", "widget.0.toplist_definition.0.title", "Log Query Toplist"),
+				),
+			},
+		},
+	})
+}
+
+const datadogDashboardToplistProcessQueryConfig = `
+resource "datadog_dashboard" "toplist_process_query_dashboard" {
+	title         = "Acceptance Test Toplist Process Query Dashboard"
+	description   = "Created using the Datadog provider in Terraform"
+	layout_type   = "ordered"
+	is_read_only  = false
+	widget {
+		toplist_definition {
+			request {
+				process_query {
+					metric = "process.stat.cpu.total_pct"
+					search_by = "error"
+					filter_by = ["active"]
+					limit = 50
+				}
+			}
+			title = "Process Query Toplist"
+		}
+	}
+}
+`
+
+func TestAccDatadogDashboard_toplistProcessQuery(t *testing.T) {
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: checkDashboardDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: datadogDashboardToplistProcessQueryConfig,
+				Check: resource.ComposeTestCheckFunc(
+					checkDashboardExists,
+					resource.TestCheckResourceAttr("datadog_dashboard.toplist_process_query_dashboard", "widget.0.toplist_definition.0.request.0.process_query.0.metric", "process.stat.cpu.total_pct"),
+					resource.TestCheckResourceAttr("datadog_dashboard.toplist_process_query_dashboard", "widget.0.toplist_definition.0.request.0.process_query.0.search_by", "error"),
+					resource.TestCheckResourceAttr("datadog_dashboard.toplist_process_query_dashboard", "widget.0.toplist_definition.0.request.0.process_query.0.filter_by.#", "1"),
+					resource.TestCheckResourceAttr("datadog_dashboard.toplist_process_query_dashboard", "widget.0.toplist_definition.0.request.0.process_query.0.filter_by.0", "active"),
+					resource.TestCheckResourceAttr("datadog_dashboard.toplist_process_query_dashboard", "widget.0.toplist_definition.0.request.0.process_query.0.limit", "50"),
+					resource.TestCheckResourceAttr("datadog_dashboard.toplist_process_query_dashboard", "widget.0.toplist_definition.0.title", "Process Query Toplist"),
+				),
+			},
+		},
+	})
+}
+
 func TestAccDatadogDashboard_import(t *testing.T) {
 	resource.Test(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
@@ -840,6 +1171,2006 @@ func TestAccDatadogDashboard_import(t *testing.T) {
 	})
 }
 
+func TestAccDatadogDashboard_importNestedGroup(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: checkDashboardDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: datadogDashboardNestedGroupConfig,
+			},
+			{
+				ResourceName:      "datadog_dashboard.nested_group_dashboard",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestValidateWidgetColor(t *testing.T) {
+	cases := []struct {
+		Value    string
+		ErrCount int
+	}{
+		{
+			Value:    "#d00",
+			ErrCount: 0,
+		},
+		{
+			Value:    "#DD0000",
+			ErrCount: 0,
+		},
+		{
+			Value:    "pink",
+			ErrCount: 0,
+		},
+		{
+			Value:    "blu",
+			ErrCount: 1,
+		},
+		{
+			Value:    "#d0",
+			ErrCount: 1,
+		},
+	}
+
+	for _, tc := range cases {
+		_, errors := validateWidgetColor(tc.Value, "color")
+
+		if len(errors) != tc.ErrCount {
+			t.Fatalf("Expected widget color validation to trigger %d error(s) for value %q - instead saw %d",
+				tc.ErrCount, tc.Value, len(errors))
+		}
+	}
+}
+
+func TestDashboardManageStatusDefinitionRoundTrip(t *testing.T) {
+	cases := []map[string]interface{}{
+		{
+			"query":            "env:prod",
+			"hide_zero_counts": true,
+		},
+		{
+			"query":            "type:metric",
+			"hide_zero_counts": false,
+		},
+		// An omitted hide_zero_counts must not diff either
+		{
+			"query": "type:metric",
+		},
+	}
+
+	for _, tc := range cases {
+		d := schema.TestResourceDataRaw(t, resourceDatadogDashboard().Schema, map[string]interface{}{
+			"title":       "Manage Status Dashboard",
+			"layout_type": "ordered",
+			"widget": []interface{}{
+				map[string]interface{}{
+					"manage_status_definition": []interface{}{tc},
+				},
+			},
+		})
+		terraformDefinition := d.Get("widget.0.manage_status_definition.0").(map[string]interface{})
+
+		var datadogDefinition datadog.ManageStatusDefinition
+		sendToAPI(t, buildDatadogManageStatusDefinition(terraformDefinition), &datadogDefinition)
+		result := buildTerraformManageStatusDefinition(datadogDefinition)
+
+		for _, key := range []string{"query", "hide_zero_counts"} {
+			if expected := d.Get("widget.0.manage_status_definition.0." + key); result[key] != expected {
+				t.Fatalf("Expected %s %v to survive a round trip - instead saw %v", key, expected, result[key])
+			}
+		}
+	}
+}
+
+func TestResourceDatadogDashboardTimeValidation(t *testing.T) {
+	cases := []struct {
+		Definition string
+		Value      map[string]interface{}
+	}{
+		{
+			Definition: "alert_value_definition",
+			Value:      map[string]interface{}{"alert_id": "1234"},
+		},
+		{
+			Definition: "free_text_definition",
+			Value:      map[string]interface{}{"text": "free text content"},
+		},
+		{
+			Definition: "image_definition",
+			Value:      map[string]interface{}{"url": "https://example.com/image.png"},
+		},
+		{
+			Definition: "iframe_definition",
+			Value:      map[string]interface{}{"url": "https://example.com"},
+		},
+		{
+			Definition: "note_definition",
+			Value:      map[string]interface{}{"content": "note content"},
+		},
+	}
+
+	for _, tc := range cases {
+		tc.Value["time"] = map[string]interface{}{"live_span": "1h"}
+		raw, err := tfconfig.NewRawConfig(map[string]interface{}{
+			"title":       "Time Validation Dashboard",
+			"layout_type": "ordered",
+			"widget": []interface{}{
+				map[string]interface{}{
+					tc.Definition: []interface{}{tc.Value},
+				},
+			},
+		})
+		if err != nil {
+			t.Fatalf("Failed to build config for %s: %s", tc.Definition, err)
+		}
+
+		_, errors := resourceDatadogDashboard().Validate(terraform.NewResourceConfig(raw))
+
+		if len(errors) == 0 {
+			t.Fatalf("Expected a time block on %s to be rejected at plan time", tc.Definition)
+		}
+	}
+}
+
+func TestDashboardConditionalFormatsOrder(t *testing.T) {
+	terraformConditionalFormats := []interface{}{
+		map[string]interface{}{"comparator": ">", "value": 10.0, "palette": "white_on_red"},
+		map[string]interface{}{"comparator": "<", "value": 2.0, "palette": "white_on_green"},
+		map[string]interface{}{"comparator": ">=", "value": 5.0, "palette": "white_on_yellow"},
+	}
+
+	datadogConditionalFormats := buildDatadogWidgetConditionalFormat(&terraformConditionalFormats)
+	result := *buildTerraformWidgetConditionalFormat(datadogConditionalFormats)
+
+	if len(result) != len(terraformConditionalFormats) {
+		t.Fatalf("Expected %d conditional formats - instead saw %d", len(terraformConditionalFormats), len(result))
+	}
+	for i, _expected := range terraformConditionalFormats {
+		expected := _expected.(map[string]interface{})
+		if result[i]["comparator"] != expected["comparator"] || result[i]["value"] != expected["value"] || result[i]["palette"] != expected["palette"] {
+			t.Fatalf("Expected conditional format %d to be %v - instead saw %v", i, expected, result[i])
+		}
+	}
+}
+
+func TestDashboardScatterplotAggregatorDefault(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceDatadogDashboard().Schema, map[string]interface{}{
+		"title":       "Scatterplot Aggregator Dashboard",
+		"layout_type": "ordered",
+		"widget": []interface{}{
+			map[string]interface{}{
+				"scatterplot_definition": []interface{}{
+					map[string]interface{}{
+						"request": []interface{}{
+							map[string]interface{}{
+								"x": []interface{}{
+									map[string]interface{}{"q": "avg:system.cpu.user{*} by {service}"},
+								},
+								"y": []interface{}{
+									map[string]interface{}{"q": "avg:system.mem.used{*} by {service}", "aggregator": "max"},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	})
+
+	dashboard, err := buildDatadogDashboard(d)
+	if err != nil {
+		t.Fatalf("Failed to build dashboard: %s", err)
+	}
+	definition := dashboard.Widgets[0].Definition.(*datadog.ScatterplotDefinition)
+	if v := definition.Requests.X.GetAggregator(); v != "avg" {
+		t.Fatalf("Expected an omitted x aggregator to default to %q - instead saw %q", "avg", v)
+	}
+	if v := definition.Requests.Y.GetAggregator(); v != "max" {
+		t.Fatalf("Expected the y aggregator to be %q - instead saw %q", "max", v)
+	}
+
+	result := buildTerraformScatterplotRequest(definition.Requests.X)
+	if v := (*result)["aggregator"]; v != d.Get("widget.0.scatterplot_definition.0.request.0.x.0.aggregator") {
+		t.Fatalf("Expected the x aggregator read back from the API to match the configuration - instead saw %v", v)
+	}
+}
+
+func TestDashboardGroupDefinitionWithoutLayoutType(t *testing.T) {
+	datadogGroupDefinition := datadog.GroupDefinition{}
+	datadogGroupDefinition.SetType(datadog.GROUP_WIDGET)
+	datadogGroupDefinition.SetTitle("Group Widget")
+
+	terraformGroupDefinition := buildTerraformGroupDefinition(datadogGroupDefinition)
+
+	if v := terraformGroupDefinition["layout_type"]; v != "ordered" {
+		t.Fatalf("Expected a group without layout_type to default to %q - instead saw %v", "ordered", v)
+	}
+}
+
+func TestDashboardHeatmapRequestStyle(t *testing.T) {
+	terraformRequests := []interface{}{
+		map[string]interface{}{
+			"q":     "avg:system.load.1{env:staging} by {account}",
+			"style": []interface{}{map[string]interface{}{"palette": "warm"}},
+		},
+		map[string]interface{}{
+			"q": "avg:system.load.5{env:staging} by {account}",
+		},
+	}
+
+	datadogRequests, err := buildDatadogHeatmapRequests(&terraformRequests)
+	if err != nil {
+		t.Fatalf("Failed to build heatmap requests: %s", err)
+	}
+	result := *buildTerraformHeatmapRequests(datadogRequests)
+
+	style, ok := result[0]["style"].([]map[string]interface{})
+	if !ok || len(style) != 1 || style[0]["palette"] != "warm" {
+		t.Fatalf("Expected the heatmap request palette to round-trip - instead saw %v", result[0]["style"])
+	}
+	if _, ok := result[1]["style"]; ok {
+		t.Fatalf("Expected a heatmap request without style to be read back without style - instead saw %v", result[1]["style"])
+	}
+}
+
+func TestValidateEventStreamEventSize(t *testing.T) {
+	cases := []struct {
+		Value    string
+		ErrCount int
+	}{
+		{
+			Value:    "s",
+			ErrCount: 0,
+		},
+		{
+			Value:    "l",
+			ErrCount: 0,
+		},
+		{
+			Value:    "m",
+			ErrCount: 1,
+		},
+		{
+			Value:    "large",
+			ErrCount: 1,
+		},
+	}
+
+	for _, tc := range cases {
+		_, errors := validateEventStreamEventSize(tc.Value, "event_size")
+
+		if len(errors) != tc.ErrCount {
+			t.Fatalf("Expected event_size validation to trigger %d error(s) for value %q - instead saw %d",
+				tc.ErrCount, tc.Value, len(errors))
+		}
+	}
+}
+
+func TestDashboardToplistConditionalFormatMetric(t *testing.T) {
+	terraformRequests := []interface{}{
+		map[string]interface{}{
+			"q": "avg:system.cpu.user{app:general} by {env}",
+			"conditional_formats": []interface{}{
+				map[string]interface{}{"comparator": ">", "value": 4.0, "palette": "white_on_red", "metric": "system.cpu.user"},
+			},
+		},
+	}
+
+	datadogRequests, err := buildDatadogToplistRequests(&terraformRequests)
+	if err != nil {
+		t.Fatalf("Failed to build toplist requests: %s", err)
+	}
+	result := *buildTerraformToplistRequests(datadogRequests)
+
+	conditionalFormats := *result[0]["conditional_formats"].(*[]map[string]interface{})
+	if v := conditionalFormats[0]["metric"]; v != "system.cpu.user" {
+		t.Fatalf("Expected the conditional format metric to round-trip - instead saw %v", v)
+	}
+}
+
+func TestValidateWidgetTextAlign(t *testing.T) {
+	cases := []struct {
+		Value    string
+		ErrCount int
+	}{
+		{
+			Value:    "center",
+			ErrCount: 0,
+		},
+		{
+			Value:    "right",
+			ErrCount: 0,
+		},
+		{
+			Value:    "middle",
+			ErrCount: 1,
+		},
+	}
+
+	for _, tc := range cases {
+		_, errors := validateWidgetTextAlign(tc.Value, "text_align")
+
+		if len(errors) != tc.ErrCount {
+			t.Fatalf("Expected text_align validation to trigger %d error(s) for value %q - instead saw %d",
+				tc.ErrCount, tc.Value, len(errors))
+		}
+	}
+}
+
+func TestDashboardQueryValueTextAlign(t *testing.T) {
+	terraformDefinition := map[string]interface{}{
+		"request": []interface{}{
+			map[string]interface{}{"q": "avg:system.load.1{env:staging} by {account}"},
+		},
+		"text_align": "center",
+	}
+
+	datadogDefinition, err := buildDatadogQueryValueDefinition(terraformDefinition)
+	if err != nil {
+		t.Fatalf("Failed to build query value: %s", err)
+	}
+	result := buildTerraformQueryValueDefinition(*datadogDefinition)
+
+	if v := result["text_align"]; v != "center" {
+		t.Fatalf("Expected query value text_align %q to round-trip - instead saw %v", "center", v)
+	}
+}
+
+func TestValidateConditionalFormatImageUrl(t *testing.T) {
+	cases := []struct {
+		Value    string
+		ErrCount int
+	}{
+		{
+			Value:    "https://example.com/images/{{value}}.png",
+			ErrCount: 0,
+		},
+		{
+			Value:    "/static/images/alert.png",
+			ErrCount: 0,
+		},
+		{
+			Value:    "http:/image.png",
+			ErrCount: 1,
+		},
+		{
+			Value:    "ftp://example.com/image.png",
+			ErrCount: 1,
+		},
+		{
+			Value:    "https://exa mple.com/image.png",
+			ErrCount: 1,
+		},
+	}
+
+	for _, tc := range cases {
+		_, errors := validateConditionalFormatImageUrl(tc.Value, "image_url")
+
+		if len(errors) != tc.ErrCount {
+			t.Fatalf("Expected image_url validation to trigger %d error(s) for value %q - instead saw %d",
+				tc.ErrCount, tc.Value, len(errors))
+		}
+	}
+}
+
+func TestDashboardWidgetDefinitionsRoundTrip(t *testing.T) {
+	metricRequest := []interface{}{map[string]interface{}{"q": "avg:system.cpu.user{*}"}}
+	minimalDefinitions := map[string]map[string]interface{}{
+		"group_definition": {
+			"layout_type": "ordered",
+			"widget": []interface{}{
+				map[string]interface{}{
+					"note_definition": []interface{}{map[string]interface{}{"content": "note"}},
+				},
+			},
+		},
+		"alert_graph_definition":    {"alert_id": "123", "viz_type": "timeseries"},
+		"alert_value_definition":    {"alert_id": "123"},
+		"change_definition":         {"request": metricRequest},
+		"check_status_definition":   {"check": "aws.ecs.agent_connected", "grouping": "cluster"},
+		"distribution_definition":   {"request": metricRequest},
+		"event_stream_definition":   {"query": "*"},
+		"event_timeline_definition": {"query": "*"},
+		"free_text_definition":      {"text": "free text"},
+		"heatmap_definition":        {"request": metricRequest},
+		"hostmap_definition": {
+			"request": []interface{}{map[string]interface{}{"fill": metricRequest}},
+		},
+		"iframe_definition":        {"url": "https://example.com"},
+		"image_definition":         {"url": "https://example.com/image.png"},
+		"log_stream_definition":    {"logset": "19"},
+		"manage_status_definition": {"query": "type:metric"},
+		"note_definition":          {"content": "note"},
+		"query_value_definition":   {"request": metricRequest},
+		"scatterplot_definition": {
+			"request": []interface{}{map[string]interface{}{"x": metricRequest, "y": metricRequest}},
+		},
+		"timeseries_definition":    {"request": metricRequest},
+		"toplist_definition":       {"request": metricRequest},
+		"trace_service_definition": {"env": "prod", "service": "web", "span_name": "http.request"},
+	}
+
+	for _, definition := range getWidgetDefinitions() {
+		terraformDefinition, ok := minimalDefinitions[definition.definitionKey]
+		if !ok {
+			t.Fatalf("Missing a minimal configuration for %s", definition.definitionKey)
+		}
+		datadogWidget, err := buildDatadogWidget(map[string]interface{}{
+			definition.definitionKey: []interface{}{terraformDefinition},
+		})
+		if err != nil {
+			t.Fatalf("Failed to build %s: %s", definition.definitionKey, err)
+		}
+
+		var readWidget datadog.BoardWidget
+		sendToAPI(t, datadogWidget, &readWidget)
+
+		terraformWidget, err := buildTerraformWidget(readWidget)
+		if err != nil {
+			t.Fatalf("Failed to read %s: %s", definition.definitionKey, err)
+		}
+		if _, ok := terraformWidget[definition.definitionKey]; !ok {
+			t.Fatalf("Expected %s to round-trip - instead saw %v", definition.definitionKey, terraformWidget)
+		}
+	}
+}
+
+func TestDashboardScatterplotColorByGroupsUnset(t *testing.T) {
+	metricRequest := []interface{}{map[string]interface{}{"q": "avg:system.cpu.user{*}"}}
+	terraformDefinition := map[string]interface{}{
+		"request":         []interface{}{map[string]interface{}{"x": metricRequest, "y": metricRequest}},
+		"color_by_groups": []interface{}{},
+	}
+
+	datadogDefinition := buildDatadogScatterplotDefinition(terraformDefinition)
+	if datadogDefinition.ColorByGroups != nil {
+		t.Fatalf("Expected an unset color_by_groups not to be sent - instead saw %v", datadogDefinition.ColorByGroups)
+	}
+
+	// The API may echo back an empty list
+	datadogDefinition.ColorByGroups = []string{}
+	result := buildTerraformScatterplotDefinition(*datadogDefinition)
+	if v, ok := result["color_by_groups"]; ok {
+		t.Fatalf("Expected an empty color_by_groups not to be read back - instead saw %v", v)
+	}
+}
+
+func TestDashboardBoardId(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceDatadogDashboard().Schema, map[string]interface{}{
+		"title":       "Board Id Dashboard",
+		"layout_type": "ordered",
+		"widget": []interface{}{
+			map[string]interface{}{
+				"note_definition": []interface{}{map[string]interface{}{"content": "note"}},
+			},
+		},
+	})
+
+	// Create
+	dashboard, err := buildDatadogDashboard(d)
+	if err != nil {
+		t.Fatalf("Failed to build dashboard: %s", err)
+	}
+	var board map[string]interface{}
+	sendToAPI(t, dashboard, &board)
+	if v, ok := board["id"]; ok {
+		t.Fatalf("Expected the create payload to have no id - instead saw %v", v)
+	}
+
+	// Update
+	d.SetId("abc-def-ghi")
+	dashboard, err = buildDatadogDashboard(d)
+	if err != nil {
+		t.Fatalf("Failed to build dashboard: %s", err)
+	}
+	if v := dashboard.GetId(); v != "abc-def-ghi" {
+		t.Fatalf("Expected the update payload to have id %q - instead saw %q", "abc-def-ghi", v)
+	}
+}
+
+func TestDashboardTimeseriesRollupQuery(t *testing.T) {
+	query := "avg:system.cpu.user{app:general} by {env}.rollup(avg, 60)"
+	terraformDefinition := map[string]interface{}{
+		"request": []interface{}{
+			map[string]interface{}{"q": query},
+		},
+	}
+
+	datadogDefinition, err := buildDatadogTimeseriesDefinition(terraformDefinition)
+	if err != nil {
+		t.Fatalf("Failed to build timeseries: %s", err)
+	}
+	result := buildTerraformTimeseriesDefinition(*datadogDefinition)
+
+	terraformRequests := *result["request"].(*[]map[string]interface{})
+	if v := terraformRequests[0]["q"]; v != query {
+		t.Fatalf("Expected the rollup query %q to be preserved verbatim - instead saw %v", query, v)
+	}
+}
+
+func TestDashboardWidgetLayoutSizes(t *testing.T) {
+	cases := []struct {
+		Layout   map[string]interface{}
+		ErrCount int
+	}{
+		{
+			Layout:   map[string]interface{}{"x": "5", "y": "5", "width": "32", "height": "43"},
+			ErrCount: 0,
+		},
+		{
+			Layout:   map[string]interface{}{"x": "5", "y": "5", "width": "0", "height": "43"},
+			ErrCount: 1,
+		},
+		{
+			Layout:   map[string]interface{}{"x": "5", "y": "5", "width": "32", "height": "-1"},
+			ErrCount: 1,
+		},
+		{
+			Layout:   map[string]interface{}{"x": "5", "y": "5", "width": "0", "height": "-1"},
+			ErrCount: 2,
+		},
+	}
+
+	for _, tc := range cases {
+		terraformWidgets := []interface{}{
+			map[string]interface{}{
+				"layout":          tc.Layout,
+				"note_definition": []interface{}{map[string]interface{}{"content": "note"}},
+			},
+		}
+		errs := getWidgetLayoutSizeErrors(terraformWidgets)
+		if len(errs) != tc.ErrCount {
+			t.Fatalf("Expected layout validation to trigger %d error(s) for layout %v - instead saw %v",
+				tc.ErrCount, tc.Layout, errs)
+		}
+	}
+
+	// Every invalid widget is reported at once
+	terraformWidgets := []interface{}{
+		map[string]interface{}{
+			"layout":          map[string]interface{}{"x": "5", "y": "5", "width": "0", "height": "43"},
+			"note_definition": []interface{}{map[string]interface{}{"content": "note"}},
+		},
+		map[string]interface{}{
+			"layout":          map[string]interface{}{"x": "5", "y": "5", "width": "32", "height": "43"},
+			"note_definition": []interface{}{map[string]interface{}{"content": "note"}},
+		},
+		map[string]interface{}{
+			"layout":          map[string]interface{}{"x": "5", "y": "5", "width": "32", "height": "-1"},
+			"note_definition": []interface{}{map[string]interface{}{"content": "note"}},
+		},
+	}
+	errs := getWidgetLayoutSizeErrors(terraformWidgets)
+	expected := []string{
+		"widget.0.layout.width must be greater than 0, got 0",
+		"widget.2.layout.height must be greater than 0, got -1",
+	}
+	if len(errs) != len(expected) {
+		t.Fatalf("Expected %d error(s) - instead saw %v", len(expected), errs)
+	}
+	for i := range expected {
+		if errs[i] != expected[i] {
+			t.Errorf("Expected error %q - instead saw %q", expected[i], errs[i])
+		}
+	}
+}
+
+func TestDashboardDistributionProcessQueryLimit(t *testing.T) {
+	terraformRequests := []interface{}{
+		map[string]interface{}{
+			"process_query": []interface{}{
+				map[string]interface{}{"metric": "process.stat.cpu.total_pct", "search_by": "error", "limit": 10},
+			},
+		},
+		map[string]interface{}{
+			"process_query": []interface{}{
+				map[string]interface{}{"metric": "process.stat.cpu.total_pct", "limit": 0},
+			},
+		},
+	}
+
+	datadogRequests := buildDatadogDistributionRequests(&terraformRequests)
+	if v := (*datadogRequests)[0].ProcessQuery.GetLimit(); v != 10 {
+		t.Fatalf("Expected the process query limit to be 10 - instead saw %d", v)
+	}
+	if (*datadogRequests)[1].ProcessQuery.Limit != nil {
+		t.Fatalf("Expected an omitted process query limit not to be sent - instead saw %d", *(*datadogRequests)[1].ProcessQuery.Limit)
+	}
+
+	result := *buildTerraformDistributionRequests(datadogRequests)
+	processQuery := result[0]["process_query"].([]map[string]interface{})[0]
+	if v := processQuery["limit"]; v != 10 {
+		t.Fatalf("Expected the process query limit to round-trip - instead saw %v", v)
+	}
+	processQuery = result[1]["process_query"].([]map[string]interface{})[0]
+	if v, ok := processQuery["limit"]; ok {
+		t.Fatalf("Expected an omitted process query limit not to be read back - instead saw %v", v)
+	}
+}
+
+func TestDashboardChangeShowPresentDefault(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceDatadogDashboard().Schema, map[string]interface{}{
+		"title":       "Change Show Present Dashboard",
+		"layout_type": "ordered",
+		"widget": []interface{}{
+			map[string]interface{}{
+				"change_definition": []interface{}{
+					map[string]interface{}{
+						"request": []interface{}{
+							map[string]interface{}{"q": "avg:system.load.1{env:staging} by {account}"},
+						},
+					},
+				},
+			},
+		},
+	})
+	configured := d.Get("widget.0.change_definition.0.request.0.show_present")
+
+	// The API omits show_present when it is false
+	datadogRequests := []datadog.ChangeRequest{{MetricQuery: datadog.String("avg:system.load.1{env:staging} by {account}")}}
+	result := *buildTerraformChangeRequests(&datadogRequests)
+
+	if v := result[0]["show_present"]; v != configured {
+		t.Fatalf("Expected an omitted show_present to match the configuration %v - instead saw %v", configured, v)
+	}
+}
+
+func TestBuildDashboardUrl(t *testing.T) {
+	cases := []struct {
+		ApiUrl       string
+		DashboardUrl string
+		Expected     string
+	}{
+		{
+			ApiUrl:       "https://api.datadoghq.com",
+			DashboardUrl: "/dashboard/abc-def-ghi/my-dashboard",
+			Expected:     "https://app.datadoghq.com/dashboard/abc-def-ghi/my-dashboard",
+		},
+		{
+			ApiUrl:       "https://api.datadoghq.eu/",
+			DashboardUrl: "/dashboard/abc-def-ghi",
+			Expected:     "https://app.datadoghq.eu/dashboard/abc-def-ghi",
+		},
+		{
+			ApiUrl:       "https://api.datadoghq.com",
+			DashboardUrl: "https://app.datadoghq.com/dashboard/abc-def-ghi",
+			Expected:     "https://app.datadoghq.com/dashboard/abc-def-ghi",
+		},
+		{
+			ApiUrl:       "https://api.datadoghq.com",
+			DashboardUrl: "",
+			Expected:     "",
+		},
+	}
+
+	for _, tc := range cases {
+		if v := buildDashboardUrl(tc.ApiUrl, tc.DashboardUrl); v != tc.Expected {
+			t.Fatalf("Expected the dashboard URL %q to be normalized to %q - instead saw %q", tc.DashboardUrl, tc.Expected, v)
+		}
+	}
+}
+
+func TestValidateAbsoluteUrl(t *testing.T) {
+	cases := []struct {
+		Value    string
+		ErrCount int
+	}{
+		{
+			Value:    "http://google.com",
+			ErrCount: 0,
+		},
+		{
+			Value:    "https://example.com/path?query=1",
+			ErrCount: 0,
+		},
+		{
+			Value:    "google.com",
+			ErrCount: 1,
+		},
+		{
+			Value:    "/relative/path",
+			ErrCount: 1,
+		},
+		{
+			Value:    "not a url",
+			ErrCount: 1,
+		},
+	}
+
+	for _, tc := range cases {
+		_, errors := validateAbsoluteUrl(tc.Value, "url")
+
+		if len(errors) != tc.ErrCount {
+			t.Fatalf("Expected url validation to trigger %d error(s) for value %q - instead saw %d",
+				tc.ErrCount, tc.Value, len(errors))
+		}
+	}
+}
+
+func TestDashboardToplistTime(t *testing.T) {
+	terraformRequests := []interface{}{
+		map[string]interface{}{"q": "avg:system.cpu.user{app:general} by {env}"},
+	}
+
+	// Time set
+	terraformDefinition := map[string]interface{}{
+		"request": terraformRequests,
+		"time":    map[string]interface{}{"live_span": "4h"},
+	}
+	datadogDefinition, err := buildDatadogToplistDefinition(terraformDefinition)
+	if err != nil {
+		t.Fatalf("Failed to build toplist: %s", err)
+	}
+	result := buildTerraformToplistDefinition(*datadogDefinition)
+	if v := result["time"].(map[string]string)["live_span"]; v != "4h" {
+		t.Fatalf("Expected the toplist live_span %q to round-trip - instead saw %q", "4h", v)
+	}
+
+	// Time omitted
+	terraformDefinition = map[string]interface{}{
+		"request": terraformRequests,
+	}
+	datadogDefinition, err = buildDatadogToplistDefinition(terraformDefinition)
+	if err != nil {
+		t.Fatalf("Failed to build toplist: %s", err)
+	}
+	result = buildTerraformToplistDefinition(*datadogDefinition)
+	if v, ok := result["time"]; ok {
+		t.Fatalf("Expected an omitted toplist time not to be read back - instead saw %v", v)
+	}
+}
+
+func TestValidateHostmapNodeType(t *testing.T) {
+	cases := []struct {
+		Value    string
+		ErrCount int
+	}{
+		{
+			Value:    "host",
+			ErrCount: 0,
+		},
+		{
+			Value:    "container",
+			ErrCount: 0,
+		},
+		{
+			Value:    "pod",
+			ErrCount: 1,
+		},
+	}
+
+	for _, tc := range cases {
+		_, errors := validateHostmapNodeType(tc.Value, "node_type")
+
+		if len(errors) != tc.ErrCount {
+			t.Fatalf("Expected node_type validation to trigger %d error(s) for value %q - instead saw %d",
+				tc.ErrCount, tc.Value, len(errors))
+		}
+	}
+}
+
+func TestDashboardQueryValueAggregatorDefault(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceDatadogDashboard().Schema, map[string]interface{}{
+		"title":       "Query Value Aggregator Dashboard",
+		"layout_type": "ordered",
+		"widget": []interface{}{
+			map[string]interface{}{
+				"query_value_definition": []interface{}{
+					map[string]interface{}{
+						"request": []interface{}{
+							map[string]interface{}{"q": "avg:system.load.1{env:staging} by {account}"},
+						},
+					},
+				},
+			},
+		},
+	})
+
+	dashboard, err := buildDatadogDashboard(d)
+	if err != nil {
+		t.Fatalf("Failed to build dashboard: %s", err)
+	}
+	definition := dashboard.Widgets[0].Definition.(*datadog.QueryValueDefinition)
+	if v := definition.Requests[0].GetAggregator(); v != "avg" {
+		t.Fatalf("Expected an omitted aggregator to default to %q - instead saw %q", "avg", v)
+	}
+
+	result := *buildTerraformQueryValueRequests(&definition.Requests)
+	if v := result[0]["aggregator"]; v != d.Get("widget.0.query_value_definition.0.request.0.aggregator") {
+		t.Fatalf("Expected the aggregator read back from the API to match the configuration - instead saw %v", v)
+	}
+}
+
+func TestDashboardScatterplotMixedQueries(t *testing.T) {
+	terraformDefinition := map[string]interface{}{
+		"request": []interface{}{
+			map[string]interface{}{
+				"x": []interface{}{
+					map[string]interface{}{
+						"apm_query": []interface{}{
+							map[string]interface{}{
+								"index":   "trace-search",
+								"compute": map[string]interface{}{"aggregation": "count"},
+							},
+						},
+						"aggregator": "max",
+					},
+				},
+				"y": []interface{}{
+					map[string]interface{}{"q": "avg:system.mem.used{*} by {service}", "aggregator": "min"},
+				},
+			},
+		},
+	}
+
+	datadogDefinition := buildDatadogScatterplotDefinition(terraformDefinition)
+	if datadogDefinition.Requests.X.ApmQuery == nil || datadogDefinition.Requests.X.MetricQuery != nil {
+		t.Fatalf("Expected the x request to only hold the APM query - instead saw %v", datadogDefinition.Requests.X)
+	}
+	if datadogDefinition.Requests.Y.MetricQuery == nil || datadogDefinition.Requests.Y.ApmQuery != nil {
+		t.Fatalf("Expected the y request to only hold the metric query - instead saw %v", datadogDefinition.Requests.Y)
+	}
+
+	result := buildTerraformScatterplotDefinition(*datadogDefinition)
+	terraformRequest := result["request"].([]map[string]interface{})[0]
+	x := terraformRequest["x"].([]map[string]interface{})[0]
+	y := terraformRequest["y"].([]map[string]interface{})[0]
+	if v := x["apm_query"].([]map[string]interface{})[0]["index"]; v != "trace-search" {
+		t.Fatalf("Expected the x APM query to round-trip - instead saw %v", x)
+	}
+	if v := x["aggregator"]; v != "max" {
+		t.Fatalf("Expected the x aggregator to round-trip - instead saw %v", v)
+	}
+	if v := y["q"]; v != "avg:system.mem.used{*} by {service}" {
+		t.Fatalf("Expected the y metric query to round-trip - instead saw %v", v)
+	}
+	if v := y["aggregator"]; v != "min" {
+		t.Fatalf("Expected the y aggregator to round-trip - instead saw %v", v)
+	}
+}
+
+func TestDashboardBuildWidgetsReportsAllErrors(t *testing.T) {
+	validWidget := map[string]interface{}{
+		"note_definition": []interface{}{
+			map[string]interface{}{"content": "valid"},
+		},
+	}
+	invalidWidget := map[string]interface{}{}
+	invalidGroupWidget := map[string]interface{}{
+		"group_definition": []interface{}{
+			map[string]interface{}{
+				"layout_type": "ordered",
+				"widget":      []interface{}{invalidWidget, validWidget, invalidWidget},
+			},
+		},
+	}
+
+	cases := []struct {
+		Widgets   []interface{}
+		Errors    []string
+		NotErrors []string
+	}{
+		{
+			Widgets: []interface{}{validWidget, validWidget},
+		},
+		{
+			Widgets:   []interface{}{validWidget, invalidWidget},
+			Errors:    []string{"1 widget error(s)", "widget.1:"},
+			NotErrors: []string{"widget.0:"},
+		},
+		{
+			Widgets:   []interface{}{invalidWidget, validWidget, invalidWidget},
+			Errors:    []string{"2 widget error(s)", "widget.0:", "widget.2:"},
+			NotErrors: []string{"widget.1:"},
+		},
+		// Errors of widgets nested in groups are flattened into a single list
+		{
+			Widgets: []interface{}{invalidWidget, invalidGroupWidget},
+			Errors: []string{
+				"3 widget error(s)",
+				"widget.0:",
+				"widget.1.group_definition.0.widget.0:",
+				"widget.1.group_definition.0.widget.2:",
+			},
+			NotErrors: []string{"widget.1:", "widget.1.group_definition.0.widget.1:", "2 widget error(s)"},
+		},
+	}
+
+	for _, tc := range cases {
+		_, err := buildDatadogWidgets(&tc.Widgets)
+		if len(tc.Errors) == 0 {
+			if err != nil {
+				t.Fatalf("Expected no error building %d valid widget(s) - instead saw %q", len(tc.Widgets), err)
+			}
+			continue
+		}
+		if err == nil {
+			t.Fatalf("Expected an error containing %q - instead saw none", tc.Errors)
+		}
+		for _, expected := range tc.Errors {
+			if !strings.Contains(err.Error(), expected) {
+				t.Errorf("Expected the error to contain %q - instead saw %q", expected, err)
+			}
+		}
+		for _, unexpected := range tc.NotErrors {
+			if strings.Contains(err.Error(), unexpected) {
+				t.Errorf("Expected the error not to contain %q - instead saw %q", unexpected, err)
+			}
+		}
+	}
+}
+
+func TestDashboardPlanReportsAllErrors(t *testing.T) {
+	// Widgets failing different checks are reported in a single plan
+	raw, err := tfconfig.NewRawConfig(map[string]interface{}{
+		"title":       "Invalid Widgets Dashboard",
+		"layout_type": "free",
+		"widget": []interface{}{
+			map[string]interface{}{
+				"layout": map[string]interface{}{"x": "5", "y": "5", "width": "0", "height": "43"},
+				"note_definition": []interface{}{
+					map[string]interface{}{"content": "note"},
+				},
+			},
+			map[string]interface{}{
+				"layout": map[string]interface{}{"x": "5", "y": "5", "width": "32", "height": "43"},
+				"query_value_definition": []interface{}{
+					map[string]interface{}{
+						"request": []interface{}{
+							map[string]interface{}{"aggregator": "sum"},
+						},
+					},
+				},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to build config: %s", err)
+	}
+	_, err = resourceDatadogDashboard().Diff(&terraform.InstanceState{}, terraform.NewResourceConfig(raw), nil)
+	if err == nil {
+		t.Fatalf("Expected the plan to fail - instead saw no error")
+	}
+	for _, expected := range []string{
+		"2 widget error(s)",
+		"widget.1.query_value_definition.0.request.0: exactly one of",
+		"widget.0.layout.width must be greater than 0, got 0",
+	} {
+		if !strings.Contains(err.Error(), expected) {
+			t.Errorf("Expected the plan error to contain %q - instead saw %q", expected, err)
+		}
+	}
+}
+
+func TestDashboardMetricQueryWhitespaceDiff(t *testing.T) {
+	querySchema := getTimeseriesRequestSchema()["q"]
+	if querySchema.DiffSuppressFunc == nil {
+		t.Fatal("Expected a DiffSuppressFunc on the timeseries request q - instead saw none")
+	}
+
+	cases := []struct {
+		Old      string
+		New      string
+		Suppress bool
+	}{
+		{Old: "avg:system.cpu.user{*}", New: "  avg:system.cpu.user{*}\n", Suppress: true},
+		{Old: "avg:system.cpu.user{*}", New: "\n\tavg:system.cpu.user{*}", Suppress: true},
+		{Old: "avg:system.cpu.user{*}", New: "avg:system.cpu.user{*}", Suppress: true},
+		{Old: "avg:system.cpu.user{*}", New: "avg:system.cpu.system{*}", Suppress: false},
+		{Old: "avg:system.cpu.user{*}", New: "avg: system.cpu.user{*}", Suppress: false},
+	}
+
+	for _, tc := range cases {
+		suppress := querySchema.DiffSuppressFunc("q", tc.Old, tc.New, nil)
+		if suppress != tc.Suppress {
+			t.Fatalf("Expected q diff from %q to %q to be suppressed: %t - instead saw %t", tc.Old, tc.New, tc.Suppress, suppress)
+		}
+	}
+}
+
+func TestDashboardTitleSizeDiff(t *testing.T) {
+	cases := []struct {
+		Old      string
+		New      string
+		Suppress bool
+	}{
+		{Old: "16", New: "16", Suppress: true},
+		{Old: "16", New: "16.0", Suppress: true},
+		{Old: "16", New: "20", Suppress: false},
+		{Old: "", New: "16", Suppress: false},
+		{Old: "16", New: "", Suppress: false},
+		{Old: "", New: "", Suppress: false},
+		{Old: "16", New: "large", Suppress: false},
+	}
+
+	for _, tc := range cases {
+		suppress := suppressTitleSizeDiff("title_size", tc.Old, tc.New, nil)
+		if suppress != tc.Suppress {
+			t.Fatalf("Expected title_size diff from %q to %q to be suppressed: %t - instead saw %t", tc.Old, tc.New, tc.Suppress, suppress)
+		}
+	}
+}
+
+func TestDashboardEventTimelineTime(t *testing.T) {
+	// Time set
+	terraformDefinition := map[string]interface{}{
+		"query": "status:error",
+		"time":  map[string]interface{}{"live_span": "1w"},
+	}
+	result := buildTerraformEventTimelineDefinition(*buildDatadogEventTimelineDefinition(terraformDefinition))
+	if v := result["time"].(map[string]string)["live_span"]; v != "1w" {
+		t.Fatalf("Expected the event_timeline live_span %q to round-trip - instead saw %q", "1w", v)
+	}
+
+	// Time omitted
+	terraformDefinition = map[string]interface{}{
+		"query": "status:error",
+	}
+	result = buildTerraformEventTimelineDefinition(*buildDatadogEventTimelineDefinition(terraformDefinition))
+	if v, ok := result["time"]; ok {
+		t.Fatalf("Expected an omitted event_timeline time not to be read back - instead saw %v", v)
+	}
+}
+
+func TestValidateWidgetAxisScale(t *testing.T) {
+	cases := []struct {
+		Value    string
+		ErrCount int
+	}{
+		{
+			Value:    "linear",
+			ErrCount: 0,
+		},
+		{
+			Value:    "log",
+			ErrCount: 0,
+		},
+		{
+			Value:    "pow",
+			ErrCount: 0,
+		},
+		{
+			Value:    "sqrt",
+			ErrCount: 0,
+		},
+		{
+			Value:    "logarithmic",
+			ErrCount: 1,
+		},
+	}
+
+	for _, tc := range cases {
+		_, errors := validateWidgetAxisScale(tc.Value, "scale")
+
+		if len(errors) != tc.ErrCount {
+			t.Fatalf("Expected scale validation to trigger %d error(s) for value %q - instead saw %d",
+				tc.ErrCount, tc.Value, len(errors))
+		}
+	}
+}
+
+func TestDashboardGroupChildWithoutTime(t *testing.T) {
+	terraformWidget := map[string]interface{}{
+		"group_definition": []interface{}{
+			map[string]interface{}{
+				"layout_type": "ordered",
+				"title":       "Group without time",
+				"widget": []interface{}{
+					map[string]interface{}{
+						"timeseries_definition": []interface{}{
+							map[string]interface{}{
+								"request": []interface{}{
+									map[string]interface{}{"q": "avg:system.cpu.user{*}"},
+								},
+								// An omitted time block is read from the config as an empty map
+								"time": map[string]interface{}{},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	datadogWidget, err := buildDatadogWidget(terraformWidget)
+	if err != nil {
+		t.Fatalf("Failed to build group widget: %s", err)
+	}
+
+	var readWidget datadog.BoardWidget
+	if payload := sendToAPI(t, datadogWidget, &readWidget); strings.Contains(payload, `"time"`) {
+		t.Fatalf("Expected the group child to be sent without a time - instead saw %s", payload)
+	}
+
+	// The API returns the group child without a time
+	result, err := buildTerraformWidget(readWidget)
+	if err != nil {
+		t.Fatalf("Failed to read group widget: %s", err)
+	}
+	group := result["group_definition"].([]map[string]interface{})[0]
+	child := group["widget"].([]map[string]interface{})[0]
+	timeseries := child["timeseries_definition"].([]map[string]interface{})[0]
+	if v, ok := timeseries["time"]; ok {
+		t.Fatalf("Expected the group child time not to be read back - instead saw %v", v)
+	}
+}
+
+func TestDashboardQueryValuePrecisionBounds(t *testing.T) {
+	precisionSchema := getQueryValueDefinitionSchema()["precision"]
+
+	cases := []struct {
+		Value    int
+		ErrCount int
+	}{
+		{
+			Value:    0,
+			ErrCount: 0,
+		},
+		{
+			Value:    15,
+			ErrCount: 0,
+		},
+		{
+			Value:    -1,
+			ErrCount: 1,
+		},
+		{
+			Value:    16,
+			ErrCount: 1,
+		},
+	}
+
+	for _, tc := range cases {
+		_, errors := precisionSchema.ValidateFunc(tc.Value, "precision")
+
+		if len(errors) != tc.ErrCount {
+			t.Fatalf("Expected precision validation to trigger %d error(s) for value %d - instead saw %d",
+				tc.ErrCount, tc.Value, len(errors))
+		}
+	}
+}
+
+func TestDashboardHostmapFillOnly(t *testing.T) {
+	terraformDefinition := map[string]interface{}{
+		"request": []interface{}{
+			map[string]interface{}{
+				"fill": []interface{}{
+					map[string]interface{}{"q": "avg:system.load.1{*} by {host}"},
+				},
+				// An empty size block is read from the config with every key set
+				"size": []interface{}{
+					map[string]interface{}{
+						"q":             "",
+						"apm_query":     []interface{}{},
+						"log_query":     []interface{}{},
+						"process_query": []interface{}{},
+					},
+				},
+			},
+		},
+	}
+
+	var datadogDefinition datadog.HostmapDefinition
+	if payload := sendToAPI(t, buildDatadogHostmapDefinition(terraformDefinition), &datadogDefinition); strings.Contains(payload, `"size"`) {
+		t.Fatalf("Expected a fill-only hostmap to be sent without a size - instead saw %s", payload)
+	}
+
+	// The API returns the requests without a size
+	result := buildTerraformHostmapDefinition(datadogDefinition)
+	terraformRequests := result["request"].([]map[string]interface{})[0]
+	if v, ok := terraformRequests["size"]; ok {
+		t.Fatalf("Expected no size to be read back - instead saw %v", v)
+	}
+	fill := terraformRequests["fill"].([]map[string]interface{})[0]
+	if v := fill["q"]; v != "avg:system.load.1{*} by {host}" {
+		t.Fatalf("Expected the fill query to round-trip - instead saw %v", v)
+	}
+
+	// An empty size object returned by the API isn't read back either
+	datadogDefinition.Requests.Size = &datadog.HostmapRequest{}
+	result = buildTerraformHostmapDefinition(datadogDefinition)
+	terraformRequests = result["request"].([]map[string]interface{})[0]
+	if v, ok := terraformRequests["size"]; ok {
+		t.Fatalf("Expected an empty size not to be read back - instead saw %v", v)
+	}
+}
+
+func TestDashboardNoteTickWarnings(t *testing.T) {
+	terraformWidgets := []interface{}{
+		map[string]interface{}{
+			"note_definition": []interface{}{
+				map[string]interface{}{"content": "ticked", "show_tick": true, "tick_pos": "50%", "tick_edge": "left"},
+			},
+		},
+		map[string]interface{}{
+			"note_definition": []interface{}{
+				map[string]interface{}{"content": "no tick", "show_tick": false, "tick_pos": "50%"},
+			},
+		},
+		map[string]interface{}{
+			"group_definition": []interface{}{
+				map[string]interface{}{
+					"layout_type": "ordered",
+					"widget": []interface{}{
+						map[string]interface{}{
+							"note_definition": []interface{}{
+								map[string]interface{}{"content": "nested", "tick_edge": "bottom"},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	warnings := getNoteTickWarnings("widget", terraformWidgets)
+	expected := []string{
+		"widget.1.note_definition.0.tick_pos has no effect unless show_tick is true",
+		"widget.2.group_definition.0.widget.0.note_definition.0.tick_edge has no effect unless show_tick is true",
+	}
+	if len(warnings) != len(expected) {
+		t.Fatalf("Expected %d warning(s) - instead saw %v", len(expected), warnings)
+	}
+	for i := range expected {
+		if warnings[i] != expected[i] {
+			t.Errorf("Expected warning %q - instead saw %q", expected[i], warnings[i])
+		}
+	}
+
+	// The warnings don't fail the plan, so existing configurations still apply
+	raw, err := tfconfig.NewRawConfig(map[string]interface{}{
+		"title":       "Note Tick Dashboard",
+		"layout_type": "ordered",
+		"widget": []interface{}{
+			map[string]interface{}{
+				"note_definition": []interface{}{
+					map[string]interface{}{"content": "no tick", "show_tick": false, "tick_pos": "50%", "tick_edge": "left"},
+				},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to build config: %s", err)
+	}
+	if _, err := resourceDatadogDashboard().Diff(&terraform.InstanceState{}, terraform.NewResourceConfig(raw), nil); err != nil {
+		t.Fatalf("Expected the plan to succeed - instead saw %v", err)
+	}
+}
+
+func TestDashboardWidgetRequestQueryErrors(t *testing.T) {
+	logQuery := []interface{}{
+		map[string]interface{}{"index": "main", "search": map[string]interface{}{"query": "status:error"}},
+	}
+	terraformWidgets := []interface{}{
+		map[string]interface{}{
+			"timeseries_definition": []interface{}{
+				map[string]interface{}{
+					"request": []interface{}{
+						map[string]interface{}{"q": "avg:system.cpu.user{*}"},
+						map[string]interface{}{"q": "avg:system.cpu.user{*}", "log_query": logQuery},
+					},
+				},
+			},
+		},
+		map[string]interface{}{
+			"toplist_definition": []interface{}{
+				map[string]interface{}{
+					"request": []interface{}{
+						map[string]interface{}{"q": ""},
+					},
+				},
+			},
+		},
+		// Distribution requests aren't checked
+		map[string]interface{}{
+			"distribution_definition": []interface{}{
+				map[string]interface{}{
+					"request": []interface{}{
+						map[string]interface{}{"q": "avg:system.load.1{*} by {host}", "log_query": logQuery},
+					},
+				},
+			},
+		},
+		map[string]interface{}{
+			"group_definition": []interface{}{
+				map[string]interface{}{
+					"widget": []interface{}{
+						map[string]interface{}{
+							"change_definition": []interface{}{
+								map[string]interface{}{
+									"request": []interface{}{
+										map[string]interface{}{"change_type": "absolute"},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	isKnown := func(string) bool { return true }
+	errs := getWidgetRequestQueryErrors("widget", terraformWidgets, isKnown)
+	expected := []string{
+		"widget.0.timeseries_definition.0.request.1: exactly one of apm_query, log_query, process_query, q is required, got log_query, q",
+		"widget.1.toplist_definition.0.request.0: exactly one of apm_query, log_query, process_query, q is required, got none",
+		"widget.3.group_definition.0.widget.0.change_definition.0.request.0: exactly one of apm_query, log_query, process_query, q is required, got none",
+	}
+	if len(errs) != len(expected) {
+		t.Fatalf("Expected %d error(s) - instead saw %v", len(expected), errs)
+	}
+	for i := range expected {
+		if errs[i] != expected[i] {
+			t.Errorf("Expected error %q - instead saw %q", expected[i], errs[i])
+		}
+	}
+
+	// Requests whose query isn't computed yet are left to the request builders
+	isKnown = func(key string) bool { return key != "widget.1.toplist_definition.0.request.0.q" }
+	errs = getWidgetRequestQueryErrors("widget", terraformWidgets, isKnown)
+	if len(errs) != len(expected)-1 || errs[1] != expected[2] {
+		t.Fatalf("Expected the toplist request with an unknown query to be skipped - instead saw %v", errs)
+	}
+
+	// The errors are surfaced at plan time
+	raw, err := tfconfig.NewRawConfig(map[string]interface{}{
+		"title":       "Request Query Dashboard",
+		"layout_type": "ordered",
+		"widget": []interface{}{
+			map[string]interface{}{
+				"query_value_definition": []interface{}{
+					map[string]interface{}{
+						"request": []interface{}{
+							map[string]interface{}{"aggregator": "sum"},
+						},
+					},
+				},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to build config: %s", err)
+	}
+	_, err = resourceDatadogDashboard().Diff(&terraform.InstanceState{}, terraform.NewResourceConfig(raw), nil)
+	planError := "widget.0.query_value_definition.0.request.0: exactly one of apm_query, log_query, process_query, q is required, got none"
+	if err == nil || !strings.Contains(err.Error(), planError) {
+		t.Fatalf("Expected the plan to fail with %q - instead saw %v", planError, err)
+	}
+}
+
+func TestDashboardWidgetRequestQueryBlocks(t *testing.T) {
+	// Every checked request must hold the query types directly
+	definitions := map[string]widgetDefinition{}
+	for _, definition := range getNonGroupWidgetDefinitions() {
+		definitions[definition.definitionKey] = definition
+	}
+	for _, definitionKey := range widgetRequestQueryDefinitions {
+		definition, ok := definitions[definitionKey]
+		if !ok {
+			t.Errorf("Expected %s to be a widget definition - instead saw none", definitionKey)
+			continue
+		}
+		requestResource := definition.getSchema()["request"].Elem.(*schema.Resource)
+		for _, key := range getWidgetRequestQueryTypes() {
+			if _, ok := requestResource.Schema[key]; !ok {
+				t.Errorf("Expected the %s requests to hold %s - instead saw none", definitionKey, key)
+			}
+		}
+	}
+}
+
+func TestDashboardAlertGraphTime(t *testing.T) {
+	// An omitted time block is read from the config as an empty map
+	terraformDefinition := map[string]interface{}{
+		"alert_id": "1234",
+		"viz_type": "timeseries",
+		"time":     map[string]interface{}{},
+	}
+	var datadogDefinition datadog.AlertGraphDefinition
+	if payload := sendToAPI(t, buildDatadogAlertGraphDefinition(terraformDefinition), &datadogDefinition); strings.Contains(payload, `"time"`) {
+		t.Fatalf("Expected an omitted alert_graph time not to be sent - instead saw %s", payload)
+	}
+
+	// The API returns the definition without a time
+	result := buildTerraformAlertGraphDefinition(datadogDefinition)
+	if v, ok := result["time"]; ok {
+		t.Fatalf("Expected an omitted alert_graph time not to be read back - instead saw %v", v)
+	}
+
+	// The API returns the live_span it normalized
+	datadogDefinition.Time = &datadog.WidgetTime{LiveSpan: datadog.String("1h")}
+	result = buildTerraformAlertGraphDefinition(datadogDefinition)
+	if v := result["time"].(map[string]string)["live_span"]; v != "1h" {
+		t.Fatalf("Expected the alert_graph live_span 1h to be read back - instead saw %v", v)
+	}
+}
+
+func TestDashboardChangeWidgetInGroup(t *testing.T) {
+	if _, ok := getNonGroupWidgetSchema()["change_definition"]; !ok {
+		t.Fatal("Expected change_definition to be allowed inside group widgets")
+	}
+
+	terraformWidget := map[string]interface{}{
+		"group_definition": []interface{}{
+			map[string]interface{}{
+				"layout_type": "ordered",
+				"widget": []interface{}{
+					map[string]interface{}{
+						"change_definition": []interface{}{
+							map[string]interface{}{
+								"request": []interface{}{
+									map[string]interface{}{
+										"q":           "avg:system.load.1{*} by {account}",
+										"change_type": "absolute",
+										"compare_to":  "week_before",
+									},
+								},
+								"title":       "Week over week",
+								"title_size":  "16",
+								"title_align": "left",
+								"time":        map[string]interface{}{"live_span": "1w"},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	datadogWidget, err := buildDatadogWidget(terraformWidget)
+	if err != nil {
+		t.Fatalf("Failed to build group widget: %s", err)
+	}
+
+	var readWidget datadog.BoardWidget
+	sendToAPI(t, datadogWidget, &readWidget)
+
+	result, err := buildTerraformWidget(readWidget)
+	if err != nil {
+		t.Fatalf("Failed to read group widget: %s", err)
+	}
+	group := result["group_definition"].([]map[string]interface{})[0]
+	child := group["widget"].([]map[string]interface{})[0]
+	_change, ok := child["change_definition"]
+	if !ok {
+		t.Fatalf("Expected the nested widget to be read back as a change_definition - instead saw %v", child)
+	}
+	change := _change.([]map[string]interface{})[0]
+	expected := map[string]string{"title": "Week over week", "title_size": "16", "title_align": "left"}
+	for key, value := range expected {
+		if v := change[key]; v != value {
+			t.Errorf("Expected change %s %q to round-trip - instead saw %v", key, value, v)
+		}
+	}
+	if v := change["time"].(map[string]string)["live_span"]; v != "1w" {
+		t.Errorf("Expected change live_span %q to round-trip - instead saw %q", "1w", v)
+	}
+}
+
+func TestDashboardTimeseriesIncludeZeroUnset(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceDatadogDashboard().Schema, map[string]interface{}{
+		"title":       "Timeseries Include Zero Dashboard",
+		"layout_type": "ordered",
+		"widget": []interface{}{
+			map[string]interface{}{
+				"timeseries_definition": []interface{}{
+					map[string]interface{}{
+						"request": []interface{}{
+							map[string]interface{}{"q": "avg:system.cpu.user{*}"},
+						},
+						"yaxis": []interface{}{
+							map[string]interface{}{"scale": "log"},
+						},
+					},
+				},
+			},
+		},
+	})
+
+	dashboard, err := buildDatadogDashboard(d)
+	if err != nil {
+		t.Fatalf("Failed to build dashboard: %s", err)
+	}
+
+	// An omitted include_zero is sent as false, as it always has been
+	var datadogWidget datadog.BoardWidget
+	sendToAPI(t, dashboard.Widgets[0], &datadogWidget)
+	definition := datadogWidget.Definition.(datadog.TimeseriesDefinition)
+	if definition.Yaxis == nil || definition.Yaxis.IncludeZero == nil || *definition.Yaxis.IncludeZero {
+		t.Fatalf("Expected an omitted include_zero to be sent as false - instead saw %v", definition.Yaxis)
+	}
+
+	// The value read back matches the configuration, so there is no diff
+	terraformYaxis := buildTerraformWidgetAxis(*definition.Yaxis)
+	if v := d.Get("widget.0.timeseries_definition.0.yaxis.0.include_zero"); terraformYaxis["include_zero"] != v {
+		t.Fatalf("Expected include_zero %v to be read back - instead saw %v", v, terraformYaxis["include_zero"])
+	}
+}
+
+func TestValidateChangeType(t *testing.T) {
+	cases := []struct {
+		Value    string
+		ErrCount int
+	}{
+		{
+			Value:    "absolute",
+			ErrCount: 0,
+		},
+		{
+			Value:    "relative",
+			ErrCount: 0,
+		},
+		{
+			Value:    "delta",
+			ErrCount: 1,
+		},
+	}
+
+	for _, tc := range cases {
+		_, errors := validateChangeType(tc.Value, "change_type")
+
+		if len(errors) != tc.ErrCount {
+			t.Fatalf("Expected change_type validation to trigger %d error(s) for value %q - instead saw %d",
+				tc.ErrCount, tc.Value, len(errors))
+		}
+	}
+}
+
+func TestDashboardHeatmapYaxis(t *testing.T) {
+	terraformDefinition := map[string]interface{}{
+		"request": []interface{}{
+			map[string]interface{}{"q": "avg:system.load.1{env:staging} by {account}"},
+		},
+		"yaxis": []interface{}{
+			map[string]interface{}{"scale": "log", "min": "1", "max": "2000", "include_zero": false},
+		},
+	}
+
+	datadogHeatmapDefinition, err := buildDatadogHeatmapDefinition(terraformDefinition)
+	if err != nil {
+		t.Fatalf("Failed to build heatmap: %s", err)
+	}
+	var datadogDefinition datadog.HeatmapDefinition
+	sendToAPI(t, datadogHeatmapDefinition, &datadogDefinition)
+
+	result := buildTerraformHeatmapDefinition(datadogDefinition)
+	yaxis := result["yaxis"].([]map[string]interface{})[0]
+	expected := map[string]string{"scale": "log", "min": "1", "max": "2000"}
+	for key, value := range expected {
+		if v := yaxis[key]; v != value {
+			t.Errorf("Expected heatmap yaxis %s %q to round-trip - instead saw %v", key, value, v)
+		}
+	}
+}
+
+func TestDashboardManageStatusZeroValues(t *testing.T) {
+	cases := []struct {
+		Definition map[string]interface{}
+		Payload    []string
+		NotPayload []string
+	}{
+		// Explicit values are sent
+		{
+			Definition: map[string]interface{}{"query": "type:metric", "count": 50, "start": 10},
+			Payload:    []string{`"count":50`, `"start":10`},
+		},
+		// Unset values aren't sent, so the API default is used
+		{
+			Definition: map[string]interface{}{"query": "type:metric"},
+			NotPayload: []string{`"count"`, `"start"`},
+		},
+	}
+
+	for _, tc := range cases {
+		d := schema.TestResourceDataRaw(t, resourceDatadogDashboard().Schema, map[string]interface{}{
+			"title":       "Manage Status Dashboard",
+			"layout_type": "ordered",
+			"widget": []interface{}{
+				map[string]interface{}{
+					"manage_status_definition": []interface{}{tc.Definition},
+				},
+			},
+		})
+		terraformDefinition := d.Get("widget.0.manage_status_definition.0").(map[string]interface{})
+
+		var datadogDefinition datadog.ManageStatusDefinition
+		payload := sendToAPI(t, buildDatadogManageStatusDefinition(terraformDefinition), &datadogDefinition)
+		for _, key := range tc.Payload {
+			if !strings.Contains(payload, key) {
+				t.Fatalf("Expected the manage_status payload to contain %s - instead saw %s", key, payload)
+			}
+		}
+		for _, key := range tc.NotPayload {
+			if strings.Contains(payload, key) {
+				t.Fatalf("Expected the manage_status payload not to contain %s - instead saw %s", key, payload)
+			}
+		}
+
+		// The values read back match the configuration, so there is no diff
+		result := buildTerraformManageStatusDefinition(datadogDefinition)
+		for _, key := range []string{"count", "start"} {
+			v, _ := result[key].(int)
+			if expected := d.Get("widget.0.manage_status_definition.0." + key); v != expected {
+				t.Errorf("Expected manage_status %s %v to round-trip - instead saw %v", key, expected, result[key])
+			}
+		}
+	}
+}
+
+func TestDashboardManageStatusNegativeValues(t *testing.T) {
+	cases := []struct {
+		Value    int
+		ErrCount int
+	}{
+		{
+			Value:    -1,
+			ErrCount: 1,
+		},
+		{
+			Value:    0,
+			ErrCount: 0,
+		},
+		{
+			Value:    50,
+			ErrCount: 0,
+		},
+	}
+
+	manageStatusSchema := getManageStatusDefinitionSchema()
+	for _, key := range []string{"count", "start"} {
+		for _, tc := range cases {
+			_, errors := manageStatusSchema[key].ValidateFunc(tc.Value, key)
+
+			if len(errors) != tc.ErrCount {
+				t.Fatalf("Expected manage_status %s validation to trigger %d error(s) for value %d - instead saw %d",
+					key, tc.ErrCount, tc.Value, len(errors))
+			}
+		}
+	}
+}
+
+func TestDashboardTraceServiceBooleansUnset(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceDatadogDashboard().Schema, map[string]interface{}{
+		"title":       "Trace Service Dashboard",
+		"layout_type": "free",
+		"widget": []interface{}{
+			map[string]interface{}{
+				"trace_service_definition": []interface{}{
+					map[string]interface{}{
+						"env":       "prod",
+						"service":   "web",
+						"span_name": "http.request",
+					},
+				},
+			},
+		},
+	})
+
+	dashboard, err := buildDatadogDashboard(d)
+	if err != nil {
+		t.Fatalf("Failed to build dashboard: %s", err)
+	}
+
+	var readWidget datadog.BoardWidget
+	sendToAPI(t, dashboard.Widgets[0], &readWidget)
+
+	result, err := buildTerraformWidget(readWidget)
+	if err != nil {
+		t.Fatalf("Failed to read widget: %s", err)
+	}
+	traceService := result["trace_service_definition"].([]map[string]interface{})[0]
+	for _, key := range []string{"show_hits", "show_errors", "show_latency", "show_breakdown", "show_distribution", "show_resource_list"} {
+		if v := traceService[key]; v != d.Get("widget.0.trace_service_definition.0."+key) {
+			t.Errorf("Expected the %s read back from the API to match the configuration - instead saw %v", key, v)
+		}
+	}
+}
+
+func TestDashboardTemplateVariableWithoutPrefix(t *testing.T) {
+	terraformTemplateVariables := []interface{}{
+		map[string]interface{}{"name": "host", "prefix": "", "default": ""},
+	}
+
+	var datadogTemplateVariables []datadog.TemplateVariable
+	payload := sendToAPI(t, buildDatadogTemplateVariables(&terraformTemplateVariables), &datadogTemplateVariables)
+	for _, key := range []string{`"prefix"`, `"default"`} {
+		if strings.Contains(payload, key) {
+			t.Fatalf("Expected an unset %s not to be sent - instead saw %s", key, payload)
+		}
+	}
+
+	result := *buildTerraformTemplateVariables(&datadogTemplateVariables)
+	if v, ok := result[0]["prefix"]; ok {
+		t.Fatalf("Expected an unset prefix not to be read back - instead saw %q", v)
+	}
+
+	// An API returning an explicit empty prefix still matches the unset configuration
+	datadogTemplateVariables[0].SetPrefix("")
+	result = *buildTerraformTemplateVariables(&datadogTemplateVariables)
+	if v := result[0]["prefix"]; v != "" {
+		t.Fatalf("Expected an empty prefix to be read back as empty - instead saw %q", v)
+	}
+}
+
+func TestDashboardTimeseriesMetadataUnset(t *testing.T) {
+	terraformRequests := []interface{}{
+		map[string]interface{}{"q": "avg:system.cpu.user{*}", "metadata": []interface{}{}},
+	}
+
+	datadogTimeseriesRequests, err := buildDatadogTimeseriesRequests(&terraformRequests)
+	if err != nil {
+		t.Fatalf("Failed to build timeseries requests: %s", err)
+	}
+	var datadogRequests []datadog.TimeseriesRequest
+	if payload := sendToAPI(t, datadogTimeseriesRequests, &datadogRequests); strings.Contains(payload, `"metadata"`) {
+		t.Fatalf("Expected an omitted metadata not to be sent - instead saw %s", payload)
+	}
+
+	result := *buildTerraformTimeseriesRequests(&datadogRequests)
+	if v, ok := result[0]["metadata"]; ok {
+		t.Fatalf("Expected an omitted metadata not to be read back - instead saw %v", v)
+	}
+
+	// An API returning an empty metadata list isn't read back either
+	datadogRequests[0].Metadata = []datadog.WidgetMetadata{}
+	result = *buildTerraformTimeseriesRequests(&datadogRequests)
+	if v, ok := result[0]["metadata"]; ok {
+		t.Fatalf("Expected an empty metadata not to be read back - instead saw %v", v)
+	}
+}
+
+func TestDashboardWidgetDefinitionErrors(t *testing.T) {
+	terraformWidgets := []interface{}{
+		map[string]interface{}{
+			"note_definition": []interface{}{
+				map[string]interface{}{"content": "valid"},
+			},
+		},
+		map[string]interface{}{},
+		map[string]interface{}{
+			"note_definition": []interface{}{
+				map[string]interface{}{"content": "note"},
+			},
+			"free_text_definition": []interface{}{
+				map[string]interface{}{"text": "free text"},
+			},
+		},
+		map[string]interface{}{
+			"group_definition": []interface{}{
+				map[string]interface{}{
+					"layout_type": "ordered",
+					"widget": []interface{}{
+						map[string]interface{}{},
+					},
+				},
+			},
+		},
+	}
+
+	errs := getWidgetDefinitionErrors("widget", terraformWidgets)
+	expected := []string{
+		"widget.1: exactly one widget definition is required, got none",
+		"widget.2: exactly one widget definition is required, got free_text_definition, note_definition",
+		"widget.3.group_definition.0.widget.0: exactly one widget definition is required, got none",
+	}
+	if len(errs) != len(expected) {
+		t.Fatalf("Expected %d error(s) - instead saw %v", len(expected), errs)
+	}
+	for i := range expected {
+		if errs[i] != expected[i] {
+			t.Errorf("Expected error %q - instead saw %q", expected[i], errs[i])
+		}
+	}
+}
+
+func TestDashboardManageStatusDisplayFormatDefault(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceDatadogDashboard().Schema, map[string]interface{}{
+		"title":       "Manage Status Display Format Dashboard",
+		"layout_type": "free",
+		"widget": []interface{}{
+			map[string]interface{}{
+				"manage_status_definition": []interface{}{
+					map[string]interface{}{"query": "type:metric"},
+				},
+			},
+		},
+	})
+
+	dashboard, err := buildDatadogDashboard(d)
+	if err != nil {
+		t.Fatalf("Failed to build dashboard: %s", err)
+	}
+	definition := dashboard.Widgets[0].Definition.(*datadog.ManageStatusDefinition)
+	if v := definition.GetDisplayFormat(); v != "countsAndList" {
+		t.Fatalf("Expected an omitted display_format to default to %q - instead saw %q", "countsAndList", v)
+	}
+
+	result := buildTerraformManageStatusDefinition(*definition)
+	if v := result["display_format"]; v != d.Get("widget.0.manage_status_definition.0.display_format") {
+		t.Fatalf("Expected the display_format read back from the API to match the configuration - instead saw %v", v)
+	}
+}
+
+func TestDashboardHeatmapRequestQueryExclusivity(t *testing.T) {
+	logQuery := []interface{}{
+		map[string]interface{}{
+			"index":   "mcnulty",
+			"compute": map[string]interface{}{"aggregation": "count"},
+		},
+	}
+	cases := []struct {
+		Requests []interface{}
+		Error    string
+	}{
+		{
+			Requests: []interface{}{
+				map[string]interface{}{"q": "avg:system.load.1{*}"},
+			},
+		},
+		{
+			Requests: []interface{}{
+				map[string]interface{}{"q": "avg:system.load.1{*}"},
+				map[string]interface{}{"q": ""},
+			},
+			Error: "request.1: exactly one of apm_query, log_query, process_query, q is required, got none",
+		},
+		{
+			Requests: []interface{}{
+				map[string]interface{}{"q": "avg:system.load.1{*}", "log_query": logQuery},
+			},
+			Error: "request.0: exactly one of apm_query, log_query, process_query, q is required, got log_query, q",
+		},
+	}
+
+	for _, tc := range cases {
+		_, err := buildDatadogHeatmapRequests(&tc.Requests)
+		if tc.Error == "" {
+			if err != nil {
+				t.Fatalf("Expected no error - instead saw %s", err)
+			}
+			continue
+		}
+		if err == nil || err.Error() != tc.Error {
+			t.Fatalf("Expected error %q - instead saw %v", tc.Error, err)
+		}
+	}
+}
+
+func TestDashboardRequestQueryExclusivity(t *testing.T) {
+	apmQuery := []interface{}{
+		map[string]interface{}{
+			"index":   "trace-search",
+			"compute": map[string]interface{}{"aggregation": "count"},
+		},
+	}
+	builders := map[string]func(*[]interface{}) error{
+		"timeseries": func(terraformRequests *[]interface{}) error {
+			_, err := buildDatadogTimeseriesRequests(terraformRequests)
+			return err
+		},
+		"toplist": func(terraformRequests *[]interface{}) error {
+			_, err := buildDatadogToplistRequests(terraformRequests)
+			return err
+		},
+		"query_value": func(terraformRequests *[]interface{}) error {
+			_, err := buildDatadogQueryValueRequests(terraformRequests)
+			return err
+		},
+		"change": func(terraformRequests *[]interface{}) error {
+			_, err := buildDatadogChangeRequests(terraformRequests)
+			return err
+		},
+	}
+
+	for name, build := range builders {
+		valid := []interface{}{
+			map[string]interface{}{"apm_query": apmQuery},
+		}
+		if err := build(&valid); err != nil {
+			t.Fatalf("Expected an apm_query %s request to be valid - instead saw %s", name, err)
+		}
+
+		none := []interface{}{
+			map[string]interface{}{"q": ""},
+		}
+		if err := build(&none); err == nil || !strings.Contains(err.Error(), "request.0:") {
+			t.Fatalf("Expected a %s request without a query to be rejected - instead saw %v", name, err)
+		}
+
+		multiple := []interface{}{
+			map[string]interface{}{"q": "avg:system.cpu.user{*}"},
+			map[string]interface{}{"q": "avg:system.cpu.user{*}", "apm_query": apmQuery},
+		}
+		if err := build(&multiple); err == nil || !strings.Contains(err.Error(), "request.1:") {
+			t.Fatalf("Expected a %s request with q and apm_query to be rejected - instead saw %v", name, err)
+		}
+	}
+}
+
+func TestDashboardRequestQueryTypes(t *testing.T) {
+	// Every query type of the shared request schema is detected and named in the errors
+	querySchema := getWidgetRequestQuerySchema()
+	for _, key := range getWidgetRequestQueryTypes() {
+		terraformRequest := map[string]interface{}{}
+		switch querySchema[key].Type {
+		case schema.TypeString:
+			terraformRequest[key] = "query"
+		case schema.TypeList:
+			terraformRequest[key] = []interface{}{map[string]interface{}{}}
+		default:
+			t.Fatalf("Expected the %s query type to be a string or a list - instead saw %s", key, querySchema[key].Type)
+		}
+		if queryKeys := getWidgetRequestQueryKeys(terraformRequest); len(queryKeys) != 1 || queryKeys[0] != key {
+			t.Fatalf("Expected the %s query type to be detected - instead saw %v", key, queryKeys)
+		}
+		if err := validateWidgetRequestQuery(map[string]interface{}{}); err == nil || !strings.Contains(err.Error(), key) {
+			t.Fatalf("Expected the missing query error to name %s - instead saw %v", key, err)
+		}
+	}
+}
+
+func TestDashboardLogStreamColumnsUnset(t *testing.T) {
+	state := &terraform.InstanceState{
+		ID: "abc-def-ghi",
+		Attributes: map[string]string{
+			"title":                            "Log Stream Columns Dashboard",
+			"layout_type":                      "ordered",
+			"is_read_only":                     "false",
+			"widget.#":                         "1",
+			"widget.0.log_stream_definition.#": "1",
+			"widget.0.log_stream_definition.0.logset":    "1234",
+			"widget.0.log_stream_definition.0.query":     "status:error",
+			"widget.0.log_stream_definition.0.columns.#": "2",
+			"widget.0.log_stream_definition.0.columns.0": "host",
+			"widget.0.log_stream_definition.0.columns.1": "service",
+		},
+	}
+	raw, err := tfconfig.NewRawConfig(map[string]interface{}{
+		"title":       "Log Stream Columns Dashboard",
+		"layout_type": "ordered",
+		"widget": []interface{}{
+			map[string]interface{}{
+				"log_stream_definition": []interface{}{
+					map[string]interface{}{"logset": "1234", "query": "status:error"},
+				},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to build config: %s", err)
+	}
+
+	diff, err := resourceDatadogDashboard().Diff(state, terraform.NewResourceConfig(raw), nil)
+	if err != nil {
+		t.Fatalf("Failed to diff: %s", err)
+	}
+	if diff != nil {
+		for key, attrDiff := range diff.Attributes {
+			if strings.Contains(key, "columns") {
+				t.Fatalf("Expected omitted columns not to diff against the API default - instead saw %s: %#v", key, attrDiff)
+			}
+		}
+	}
+}
+
+// Helper to go through JSON the way the API does: it returns the payload sent for
+// datadogValue and decodes it into readValue, as the API would return it
+func sendToAPI(t *testing.T, datadogValue interface{}, readValue interface{}) string {
+	payload, err := json.Marshal(datadogValue)
+	if err != nil {
+		t.Fatalf("Failed to marshal %T: %s", datadogValue, err)
+	}
+	if err := json.Unmarshal(payload, readValue); err != nil {
+		t.Fatalf("Failed to unmarshal %T: %s", readValue, err)
+	}
+	return string(payload)
+}
+
 func checkDashboardExists(s *terraform.State) error {
 	client := testAccProvider.Meta().(*datadog.Client)
 	for _, r := range s.RootModule().Resources {